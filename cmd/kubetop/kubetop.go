@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
+	"github.com/ricoberger/kubetop/pkg/alerts"
 	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/config"
+	"github.com/ricoberger/kubetop/pkg/sink"
 	"github.com/ricoberger/kubetop/pkg/term"
 	"github.com/ricoberger/kubetop/pkg/term/widgets"
 	"github.com/ricoberger/kubetop/pkg/version"
@@ -14,14 +18,35 @@ import (
 )
 
 var (
-	kubeconfig string
-	namespace  string
+	kubeconfig        string
+	configFile        string
+	namespace         string
+	tailRetention     int
+	alertRules        string
+	gaugeWarning      float64
+	gaugeCritical     float64
+	sinkTarget        string
+	sinkBatchSize     int
+	sinkFlushInterval time.Duration
+	sinkMaxSize       int64
+	sinkMaxBackups    int
+	sinkRetries       int
+	recordDir         string
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "kubetop",
 	Short: "kubetop - another terminal based activity monitor for Kubernetes.",
 	Long:  "kubetop - another terminal based activity monitor for Kubernetes.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// The tail retention is the maximum number of events in the tail mode of the events view, so it must be a positive number.
+		// The gauge thresholds must be percentages and the warning threshold can not be greater than the critical threshold.
+		if tailRetention <= 0 {
+			return fmt.Errorf("invalid tail retention %d: must be greater than 0", tailRetention)
+		}
+
+		return widgets.Thresholds{Warning: gaugeWarning, Critical: gaugeCritical}.Validate()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize Kubernetes API client.
 		client, err := api.NewClient(kubeconfig)
@@ -30,13 +55,7 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Initialize and run the terminal user interface for kubetop.
-		t := term.Term{
-			APIClient: client,
-			ViewType:  widgets.ViewTypePods,
-		}
-
-		err = t.Run(api.Filter{Namespace: namespace, Node: "", Status: 10})
-		if err != nil {
+		if err := runTerm(newTerm(client, "")); err != nil {
 			log.Fatalf("Failed to initialize ui: %#v", err)
 		}
 	},
@@ -70,13 +89,7 @@ var nodesCmd = &cobra.Command{
 		}
 
 		// Initialize and run the terminal user interface for kubetop.
-		t := term.Term{
-			APIClient: client,
-			ViewType:  widgets.ViewTypeNodes,
-		}
-
-		err = t.Run(api.Filter{Namespace: namespace, Node: "", Status: 10})
-		if err != nil {
+		if err := runTerm(newTerm(client, widgets.ViewTypeNodes)); err != nil {
 			log.Fatalf("Failed to initialize ui: %#v", err)
 		}
 	},
@@ -94,13 +107,7 @@ var podsCmd = &cobra.Command{
 		}
 
 		// Initialize and run the terminal user interface for kubetop.
-		t := term.Term{
-			APIClient: client,
-			ViewType:  widgets.ViewTypePods,
-		}
-
-		err = t.Run(api.Filter{Namespace: namespace, Node: "", Status: 10})
-		if err != nil {
+		if err := runTerm(newTerm(client, widgets.ViewTypePods)); err != nil {
 			log.Fatalf("Failed to initialize ui: %#v", err)
 		}
 	},
@@ -117,27 +124,199 @@ var eventsCmd = &cobra.Command{
 			log.Fatalf("Failed to initialize API client: %#v", err)
 		}
 
+		// Initialize the sink for events, when a target for the sink is provided.
+		// All events seen by kubetop are exported to the sink until kubetop is closed.
+		var eventSink *sink.Sink
+		if sinkTarget != "" {
+			eventSink, err = sink.New(sinkTarget, sink.Options{
+				BatchSize:     sinkBatchSize,
+				FlushInterval: sinkFlushInterval,
+				MaxSize:       sinkMaxSize * 1024 * 1024,
+				MaxBackups:    sinkMaxBackups,
+				Retries:       sinkRetries,
+			})
+			if err != nil {
+				log.Fatalf("Failed to initialize event sink: %#v", err)
+			}
+
+			client.SetEventSink(eventSink)
+		}
+
+		// Initialize and run the terminal user interface for kubetop.
+		t := newTerm(client, widgets.ViewTypeEvents)
+		t.Sink = eventSink
+		err = runTerm(t)
+
+		if eventSink != nil {
+			eventSink.Close()
+		}
+
+		if err != nil {
+			log.Fatalf("Failed to initialize ui: %#v", err)
+		}
+	},
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay a recording of kubetop.",
+	Long:  "Replay a recording of kubetop, which was created with the '--record' flag.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// Load the recording. The replay is used instead of the Kubernetes API client, so that the recording is shown in the same views.
+		replay, err := api.NewReplay(args[0])
+		if err != nil {
+			log.Fatalf("Failed to load recording: %#v", err)
+		}
+
+		// The custom columns are taken from the recording, so we do not pass the custom columns from the configuration file to the replay.
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			log.Fatalf("Failed to load configuration: %#v", err)
+		}
+
 		// Initialize and run the terminal user interface for kubetop.
+		// Alerts are not evaluated for a replay, because the commands of the alert rules would be run again.
 		t := term.Term{
-			APIClient: client,
-			ViewType:  widgets.ViewTypeEvents,
+			APIClient:     replay,
+			Replay:        replay,
+			Config:        cfg,
+			TailRetention: tailRetention,
+			GaugeThresholds: widgets.Thresholds{
+				Warning:  gaugeWarning,
+				Critical: gaugeCritical,
+			},
 		}
 
-		err = t.Run(api.Filter{Namespace: namespace, Node: "", Status: 10})
+		err = t.Run(defaultFilter(cfg))
 		if err != nil {
 			log.Fatalf("Failed to initialize ui: %#v", err)
 		}
 	},
 }
 
+// newTerm returns the terminal user interface for kubetop, which shows the provided view for the Kubernetes cluster of the API client.
+// If the view type is empty, the default view from the configuration file is shown.
+// The configuration, the alert rules and the recording are created from the flags, which are shared by all commands.
+// The configuration is loaded first, because the recording contains the custom columns from the configuration.
+func newTerm(client *api.Client, viewType widgets.ViewType) *term.Term {
+	cfg := loadConfig(client)
+
+	return &term.Term{
+		APIClient:     client,
+		Alerts:        newAlertsEngine(),
+		Recorder:      newRecorder(client),
+		Config:        cfg,
+		TailRetention: tailRetention,
+		GaugeThresholds: widgets.Thresholds{
+			Warning:  gaugeWarning,
+			Critical: gaugeCritical,
+		},
+		ViewType: viewType,
+	}
+}
+
+// runTerm runs the terminal user interface with the filter from the configuration file and finishes the recording, when kubetop is closed.
+func runTerm(t *term.Term) error {
+	err := t.Run(defaultFilter(t.Config))
+	closeRecorder(t.Recorder)
+	return err
+}
+
+// newAlertsEngine returns the engine for the alert rules from the file provided via the '--alert-rules' flag.
+// If no file is provided, we return nil and no alerts are evaluated.
+func newAlertsEngine() *alerts.Engine {
+	if alertRules == "" {
+		return nil
+	}
+
+	engine, err := alerts.NewEngine(alertRules)
+	if err != nil {
+		log.Fatalf("Failed to load alert rules: %#v", err)
+	}
+
+	return engine
+}
+
+// newRecorder returns a recorder, which writes the data of the cluster to a new recording in the directory provided via the '--record' flag.
+// If no directory is provided, we return nil and nothing is recorded.
+func newRecorder(client *api.Client) *api.Recorder {
+	if recordDir == "" {
+		return nil
+	}
+
+	recorder, err := api.NewRecorder(client, recordDir)
+	if err != nil {
+		log.Fatalf("Failed to create recording: %#v", err)
+	}
+
+	return recorder
+}
+
+// closeRecorder finishes the recording, when kubetop is closed, and prints the name of the file, so that it can be replayed.
+func closeRecorder(recorder *api.Recorder) {
+	if recorder == nil {
+		return
+	}
+
+	if err := recorder.Close(); err != nil {
+		log.Printf("Failed to close recording: %#v", err)
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, "Recording saved to %s\n", recorder.Name())
+}
+
+// loadConfig returns the configuration from the file provided via the '--config' flag.
+// If no file is provided, we return an empty configuration, so that the defaults are used.
+// The custom columns from the configuration are evaluated by the API client, so we pass them to the client.
+func loadConfig(client *api.Client) *config.Config {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %#v", err)
+	}
+
+	err = client.SetCustomColumns(cfg.CustomColumns.Pods, cfg.CustomColumns.Nodes)
+	if err != nil {
+		log.Fatalf("Failed to load custom columns: %#v", err)
+	}
+
+	return cfg
+}
+
+// defaultFilter returns the filter from the configuration file, which is used when kubetop is started.
+// The namespace from the '--namespace' flag is preferred over the namespace from the configuration file.
+func defaultFilter(cfg *config.Config) api.Filter {
+	filter := cfg.Defaults.Filter.APIFilter()
+	if namespace != "" {
+		filter.Namespace = namespace
+	}
+
+	return filter
+}
+
 func init() {
 	rootCmd.AddCommand(nodesCmd)
 	rootCmd.AddCommand(podsCmd)
 	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(replayCmd)
 	rootCmd.AddCommand(versionCmd)
 
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path to the configuration file for kubetop (default \"$XDG_CONFIG_HOME/kubetop/config.yaml\").")
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests.")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "If present, the namespace scope for this CLI request.")
+	eventsCmd.Flags().StringVar(&sinkTarget, "sink", "", "Export all events as JSON lines to a file or a HTTP endpoint (e.g. 'events.log' or 'https://example.com/events').")
+	eventsCmd.Flags().IntVar(&sinkBatchSize, "sink-batch-size", 100, "Maximum number of events which are exported at once.")
+	eventsCmd.Flags().DurationVar(&sinkFlushInterval, "sink-flush-interval", 5*time.Second, "Interval in which collected events are exported.")
+	eventsCmd.Flags().Int64Var(&sinkMaxSize, "sink-max-size", 100, "Maximum size of the file in megabytes before it is rotated.")
+	eventsCmd.Flags().IntVar(&sinkMaxBackups, "sink-max-backups", 5, "Maximum number of rotated files which are kept.")
+	eventsCmd.Flags().IntVar(&sinkRetries, "sink-retries", 3, "Number of retries for failed requests to the HTTP endpoint.")
+
+	rootCmd.PersistentFlags().StringVar(&alertRules, "alert-rules", "", "Path to a YAML file with alert rules, which are evaluated against pods and nodes.")
+	rootCmd.PersistentFlags().Float64Var(&gaugeWarning, "gauge-warning", 70, "Utilisation in percent at which the cpu and memory gauges are colored yellow.")
+	rootCmd.PersistentFlags().Float64Var(&gaugeCritical, "gauge-critical", 90, "Utilisation in percent at which the cpu and memory gauges are colored red.")
+	rootCmd.PersistentFlags().StringVar(&recordDir, "record", "", "Record the nodes, pods and events at each refresh to a compressed file in the provided directory, which can be replayed with 'kubetop replay <file>'.")
+	rootCmd.PersistentFlags().IntVar(&tailRetention, "tail-retention", 1000, "Maximum number of events which are kept in the tail mode of the events view.")
 }
 
 func main() {
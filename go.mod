@@ -29,5 +29,5 @@ require (
 	k8s.io/klog v0.3.1 // indirect
 	k8s.io/metrics v0.0.0-20190314001731-1bd6a4002213
 	k8s.io/utils v0.0.0-20190607212802-c55fbcfc754a // indirect
-	sigs.k8s.io/yaml v1.1.0 // indirect
+	sigs.k8s.io/yaml v1.1.0
 )
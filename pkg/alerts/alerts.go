@@ -0,0 +1,277 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+// restartsWindow is the time window in which the increase of the restarts of a pod is measured.
+// A rule for the increase keeps firing for the whole window after a restart, so that the alert does not flap between two refreshes.
+const restartsWindow = 10 * time.Minute
+
+// Alert represents a rule, which is firing for a pod or node.
+type Alert struct {
+	Rule   Rule
+	Object string
+	Value  string
+	Since  time.Time
+}
+
+// Message returns a human readable description of the alert.
+func (a Alert) Message() string {
+	return fmt.Sprintf("%s: %s %s is %s (%s %s)", a.Rule.Name, a.Rule.Target, a.Object, a.Value, a.Rule.Operator, a.Rule.Value)
+}
+
+// Engine evaluates the rules against each refresh of pods and nodes.
+// The engine remembers all active alerts, so that the bell and the command of a rule are only triggered on the transition to firing.
+// The pods are the last evaluated pods, they are used to decide if a pod is covered by the filter of a later evaluation.
+type Engine struct {
+	rules    []Rule
+	active   map[string]Alert
+	restarts map[string][]restartsSample
+	pods     map[string]api.Pod
+	mutex    sync.Mutex
+}
+
+// restartsSample is the number of restarts of a pod at the time of an evaluation.
+type restartsSample struct {
+	time     time.Time
+	restarts int64
+}
+
+// NewEngine returns a new engine for the rules in the provided file.
+func NewEngine(path string) (*Engine, error) {
+	rules, err := loadRules(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		rules:    rules,
+		active:   make(map[string]Alert),
+		restarts: make(map[string][]restartsSample),
+		pods:     make(map[string]api.Pod),
+	}, nil
+}
+
+// EvaluatePods evaluates all pod rules against the provided pods, which were fetched with the provided filter.
+// It returns the highest severity of all firing rules for each pod, the key of the map is '<namespace>/<name>'.
+// The increase of the restarts is calculated from the restarts of all evaluations within the restarts window.
+// The restarts and alerts of pods, which were not evaluated, are only removed when the pods are covered by the filter, so that views with different filters do not remove the alerts of each other.
+func (e *Engine) EvaluatePods(pods []api.Pod, filter api.Filter) map[string]Severity {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	now := time.Now()
+	values := make(map[string]map[string]string)
+	for _, pod := range pods {
+		key := pod.Namespace + "/" + pod.Name
+		restartsIncrease := e.restartsIncrease(key, pod.Restarts, now)
+
+		values[key] = map[string]string{
+			"status":                pod.Status,
+			"restarts":              fmt.Sprintf("%d", pod.Restarts),
+			"restartsIncrease":      fmt.Sprintf("%d", restartsIncrease),
+			"cpu":                   fmt.Sprintf("%d", pod.CPU),
+			"cpuLimitPercent":       percent(pod.CPU, pod.CPUMax),
+			"memory":                fmt.Sprintf("%d", pod.Memory),
+			"memoryLimitPercent":    percent(pod.Memory, pod.MemoryMax),
+			"lastTerminationReason": pod.LastTerminationReason,
+		}
+	}
+
+	deleted := make(map[string]bool)
+	for key, pod := range e.pods {
+		if _, ok := values[key]; !ok && filter.MatchesPod(pod) {
+			deleted[key] = true
+			delete(e.pods, key)
+			delete(e.restarts, key)
+		}
+	}
+
+	for _, pod := range pods {
+		e.pods[pod.Namespace+"/"+pod.Name] = api.Pod{Namespace: pod.Namespace, NodeName: pod.NodeName, StatusGeneral: pod.StatusGeneral, Labels: pod.Labels}
+	}
+
+	return e.evaluate(TargetPod, values, deleted)
+}
+
+// restartsIncrease adds the restarts of a pod to the samples of the pod and returns the increase of the restarts within the restarts window.
+// If the restarts decreased, because the pod was recreated with the same name, we return zero.
+func (e *Engine) restartsIncrease(key string, restarts int64, now time.Time) int64 {
+	var samples []restartsSample
+	for _, sample := range e.restarts[key] {
+		if now.Sub(sample.time) <= restartsWindow {
+			samples = append(samples, sample)
+		}
+	}
+
+	samples = append(samples, restartsSample{now, restarts})
+	e.restarts[key] = samples
+
+	if increase := restarts - samples[0].restarts; increase > 0 {
+		return increase
+	}
+
+	return 0
+}
+
+// EvaluateNodes evaluates all node rules against the provided nodes.
+// It returns the highest severity of all firing rules for each node, the key of the map is the name of the node.
+func (e *Engine) EvaluateNodes(nodes []api.Node) map[string]Severity {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	values := make(map[string]map[string]string)
+	for _, node := range nodes {
+		values[node.Name] = map[string]string{
+			"status":        node.Status,
+			"pods":          fmt.Sprintf("%d", node.PodsCount),
+			"cpuPercent":    percent(node.CPUUsed, node.CPUTotal),
+			"memoryPercent": percent(node.MemoryUsed, node.MemoryTotal),
+		}
+	}
+
+	// The nodes are never filtered, so that all nodes which were not evaluated were deleted.
+	deleted := make(map[string]bool)
+	for _, alert := range e.active {
+		if _, ok := values[alert.Object]; !ok && alert.Rule.Target == TargetNode {
+			deleted[alert.Object] = true
+		}
+	}
+
+	return e.evaluate(TargetNode, values, deleted)
+}
+
+// Alerts returns all active alerts, sorted by the time since the alerts are firing.
+func (e *Engine) Alerts() []Alert {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	var alerts []Alert
+	for _, alert := range e.active {
+		alerts = append(alerts, alert)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].Since.Before(alerts[j].Since)
+	})
+
+	return alerts
+}
+
+// evaluate evaluates all rules for the provided target against the values of all objects.
+// New alerts are added to the active alerts and trigger the bell and the command of the rule.
+// Active alerts for the target are removed, when the rule is not firing anymore for an evaluated object or when the object was deleted. Alerts for all other objects, which were not evaluated, are kept.
+func (e *Engine) evaluate(target Target, values map[string]map[string]string, deleted map[string]bool) map[string]Severity {
+	severities := make(map[string]Severity)
+	firing := make(map[string]bool)
+
+	for _, rule := range e.rules {
+		if rule.Target != target {
+			continue
+		}
+
+		for object, metrics := range values {
+			value := metrics[rule.Metric]
+			if !rule.match(value) {
+				continue
+			}
+
+			key := string(target) + "/" + rule.Name + "/" + object
+			firing[key] = true
+
+			if severityLevel(rule.Severity) > severityLevel(severities[object]) {
+				severities[object] = rule.Severity
+			}
+
+			if alert, ok := e.active[key]; ok {
+				alert.Value = value
+				e.active[key] = alert
+				continue
+			}
+
+			alert := Alert{
+				Rule:   rule,
+				Object: object,
+				Value:  value,
+				Since:  time.Now(),
+			}
+			e.active[key] = alert
+			notify(alert)
+		}
+	}
+
+	for key, alert := range e.active {
+		if _, evaluated := values[alert.Object]; alert.Rule.Target == target && !firing[key] && (evaluated || deleted[alert.Object]) {
+			delete(e.active, key)
+		}
+	}
+
+	return severities
+}
+
+// notify rings the terminal bell and runs the command of the rule, when the alert starts firing.
+// The command is executed via the shell in the background. The details of the alert are passed as environment variables to the command.
+// The output of the command is discarded, because it would break the user interface.
+func notify(alert Alert) {
+	if alert.Rule.Bell {
+		fmt.Fprint(os.Stdout, "\a")
+	}
+
+	if alert.Rule.Command != "" {
+		cmd := exec.Command("sh", "-c", alert.Rule.Command)
+		cmd.Env = append(os.Environ(),
+			"KUBETOP_ALERT_NAME="+alert.Rule.Name,
+			"KUBETOP_ALERT_SEVERITY="+string(alert.Rule.Severity),
+			"KUBETOP_ALERT_TARGET="+string(alert.Rule.Target),
+			"KUBETOP_ALERT_OBJECT="+alert.Object,
+			"KUBETOP_ALERT_VALUE="+alert.Value,
+			"KUBETOP_ALERT_MESSAGE="+alert.Message(),
+		)
+
+		go cmd.Run()
+	}
+}
+
+// percent returns the percentage of the used value from the total value.
+// If the total value is zero (e.g. no limit is set), we return an empty string, so that numeric rules never match.
+func percent(used, total int64) string {
+	if total == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%.2f", float64(used)*100.0/float64(total))
+}
+
+// severityLevel returns a number for a severity, so that severities can be compared.
+func severityLevel(severity Severity) int {
+	switch severity {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityCritical:
+		return 3
+	}
+
+	return 0
+}
@@ -0,0 +1,162 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		operator string
+		expected string
+		value    string
+		want     bool
+	}{
+		{">", "5", "6", true},
+		{">", "5", "5", false},
+		{">=", "5", "5", true},
+		{"<", "5", "4.5", true},
+		{"<=", "5", "5.1", false},
+		{"==", "5", "5.0", true},
+		{"!=", "5", "5", false},
+		{"==", "Running", "running", true},
+		{"!=", "Running", "CrashLoopBackOff", true},
+		{">", "5", "Running", false},
+		{">", "5", "", false},
+		{"==", "", "", true},
+	} {
+		rule := Rule{Operator: tt.operator, Value: tt.expected}
+		if got := rule.match(tt.value); got != tt.want {
+			t.Errorf("%q %s %q: expected %t, got %t", tt.value, tt.operator, tt.expected, tt.want, got)
+		}
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"valid pod rule", Rule{Name: "a", Target: TargetPod, Metric: "restarts", Operator: ">", Severity: SeverityWarning}, false},
+		{"valid node rule", Rule{Name: "a", Target: TargetNode, Metric: "cpuPercent", Operator: ">=", Severity: SeverityCritical}, false},
+		{"unknown target", Rule{Name: "a", Target: "service", Metric: "restarts", Operator: ">", Severity: SeverityWarning}, true},
+		{"node metric for pod", Rule{Name: "a", Target: TargetPod, Metric: "cpuPercent", Operator: ">", Severity: SeverityWarning}, true},
+		{"unknown operator", Rule{Name: "a", Target: TargetPod, Metric: "restarts", Operator: "=~", Severity: SeverityWarning}, true},
+		{"unknown severity", Rule{Name: "a", Target: TargetPod, Metric: "restarts", Operator: ">", Severity: "fatal"}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRule(tt.rule); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRestartsIncrease(t *testing.T) {
+	e := &Engine{restarts: make(map[string][]restartsSample)}
+	start := time.Now()
+
+	for _, tt := range []struct {
+		offset   time.Duration
+		restarts int64
+		want     int64
+	}{
+		{0, 2, 0},
+		{time.Minute, 3, 1},
+		{2 * time.Minute, 3, 1},
+		{5 * time.Minute, 4, 2},
+		{restartsWindow + 30*time.Second, 4, 1},
+		{2 * restartsWindow, 4, 0},
+		{2*restartsWindow + time.Minute, 0, 0},
+	} {
+		if got := e.restartsIncrease("default/pod", tt.restarts, start.Add(tt.offset)); got != tt.want {
+			t.Errorf("after %s with %d restarts: expected increase %d, got %d", tt.offset, tt.restarts, tt.want, got)
+		}
+	}
+}
+
+// unfiltered is the filter of a view, which shows all pods.
+var unfiltered = api.Filter{Status: 10}
+
+func newTestEngine(rules ...Rule) *Engine {
+	return &Engine{
+		rules:    rules,
+		active:   make(map[string]Alert),
+		restarts: make(map[string][]restartsSample),
+		pods:     make(map[string]api.Pod),
+	}
+}
+
+func TestEvaluatePodsRemovesDeletedPods(t *testing.T) {
+	e := newTestEngine(Rule{Name: "restarted", Target: TargetPod, Metric: "restartsIncrease", Operator: ">", Value: "0", Severity: SeverityWarning})
+
+	e.EvaluatePods([]api.Pod{{Namespace: "default", Name: "a", Restarts: 1}, {Namespace: "default", Name: "b"}}, unfiltered)
+	severities := e.EvaluatePods([]api.Pod{{Namespace: "default", Name: "a", Restarts: 2}}, unfiltered)
+
+	if severities["default/a"] != SeverityWarning {
+		t.Errorf("expected rule to fire for default/a, got %q", severities["default/a"])
+	}
+	if _, ok := e.restarts["default/b"]; ok {
+		t.Errorf("expected restarts of deleted pod default/b to be removed")
+	}
+
+	severities = e.EvaluatePods([]api.Pod{{Namespace: "default", Name: "a", Restarts: 2}}, unfiltered)
+	if severities["default/a"] != SeverityWarning {
+		t.Errorf("expected rule to keep firing within the restarts window, got %q", severities["default/a"])
+	}
+}
+
+func TestEvaluatePodsWithDifferentFilters(t *testing.T) {
+	e := newTestEngine(
+		Rule{Name: "restarted", Target: TargetPod, Metric: "restartsIncrease", Operator: ">", Value: "0", Severity: SeverityWarning},
+		Rule{Name: "crashing", Target: TargetPod, Metric: "status", Operator: "==", Value: "CrashLoopBackOff", Severity: SeverityCritical},
+	)
+
+	kubeSystem := api.Filter{Namespace: "kube-system", Status: 10}
+	a := api.Pod{Namespace: "default", Name: "a", Status: "CrashLoopBackOff", StatusGeneral: 1, Restarts: 1}
+	b := api.Pod{Namespace: "kube-system", Name: "b", Status: "Running", StatusGeneral: 2}
+
+	// Two tabs refresh the pods with different filters after each other.
+	e.EvaluatePods([]api.Pod{a, b}, unfiltered)
+	since := e.active["pod/crashing/default/a"].Since
+
+	a.Restarts = 2
+	e.EvaluatePods([]api.Pod{a, b}, unfiltered)
+	e.EvaluatePods([]api.Pod{b}, kubeSystem)
+	e.EvaluatePods([]api.Pod{b}, api.Filter{Status: 2})
+
+	for _, key := range []string{"pod/crashing/default/a", "pod/restarted/default/a"} {
+		if _, ok := e.active[key]; !ok {
+			t.Errorf("expected alert %s to be kept for a pod outside of the filter", key)
+		}
+	}
+	if alert := e.active["pod/crashing/default/a"]; !alert.Since.Equal(since) {
+		t.Errorf("expected alert to keep firing since %s, got %s", since, alert.Since)
+	}
+	if len(e.restarts["default/a"]) != 2 {
+		t.Errorf("expected restarts samples of default/a to be kept, got %d", len(e.restarts["default/a"]))
+	}
+
+	// A deleted pod, which is covered by the filter, is removed.
+	e.EvaluatePods(nil, api.Filter{Namespace: "default", Status: 10})
+	if len(e.Alerts()) != 0 {
+		t.Errorf("expected alerts of deleted pod to be removed, got %v", e.Alerts())
+	}
+	if _, ok := e.restarts["default/a"]; ok {
+		t.Errorf("expected restarts of deleted pod to be removed")
+	}
+}
+
+func TestEvaluateNodesRemovesDeletedNodes(t *testing.T) {
+	e := newTestEngine(Rule{Name: "notReady", Target: TargetNode, Metric: "status", Operator: "!=", Value: "Ready", Severity: SeverityCritical})
+
+	e.EvaluateNodes([]api.Node{{Name: "a", Status: "NotReady"}, {Name: "b", Status: "NotReady"}})
+	e.EvaluateNodes([]api.Node{{Name: "a", Status: "Ready"}})
+
+	if alerts := e.Alerts(); len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %v", alerts)
+	}
+}
@@ -0,0 +1,161 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrInvalidRule is thrown if a rule in the rules file is not valid.
+	ErrInvalidRule = errors.New("invalid rule")
+)
+
+// Severity is our custom type for the severity of a rule.
+type Severity string
+
+const (
+	// SeverityInfo is the lowest severity for a rule.
+	SeverityInfo Severity = "info"
+	// SeverityWarning is the severity for rules, which should be checked soon.
+	SeverityWarning Severity = "warning"
+	// SeverityCritical is the highest severity for a rule.
+	SeverityCritical Severity = "critical"
+)
+
+// Target is our custom type for the objects a rule is evaluated against.
+type Target string
+
+const (
+	// TargetPod is used for rules, which are evaluated against pods.
+	TargetPod Target = "pod"
+	// TargetNode is used for rules, which are evaluated against nodes.
+	TargetNode Target = "node"
+)
+
+// Rule represents a single rule from the rules file.
+// A rule compares the value of a metric of a pod or node with the configured value.
+// When a rule starts firing the terminal bell can be rung and a local command can be executed.
+type Rule struct {
+	Name     string   `json:"name"`
+	Target   Target   `json:"target"`
+	Metric   string   `json:"metric"`
+	Operator string   `json:"operator"`
+	Value    string   `json:"value"`
+	Severity Severity `json:"severity"`
+	Bell     bool     `json:"bell"`
+	Command  string   `json:"command"`
+}
+
+// rules represents the structure of the rules file.
+type rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// podMetrics and nodeMetrics contain all metrics, which can be used in a rule.
+var (
+	podMetrics  = []string{"status", "restarts", "restartsIncrease", "cpu", "cpuLimitPercent", "memory", "memoryLimitPercent", "lastTerminationReason"}
+	nodeMetrics = []string{"status", "pods", "cpuPercent", "memoryPercent"}
+)
+
+// loadRules reads the rules from the provided YAML file and validates each rule.
+func loadRules(path string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r rules
+	err = yaml.Unmarshal(data, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range r.Rules {
+		if r.Rules[index].Severity == "" {
+			r.Rules[index].Severity = SeverityWarning
+		}
+
+		if err := validateRule(r.Rules[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.Rules, nil
+}
+
+// validateRule checks if the target, metric, operator and severity of a rule are valid.
+func validateRule(rule Rule) error {
+	var metrics []string
+
+	switch rule.Target {
+	case TargetPod:
+		metrics = podMetrics
+	case TargetNode:
+		metrics = nodeMetrics
+	default:
+		return fmt.Errorf("%v: rule %s has an unknown target %s", ErrInvalidRule, rule.Name, rule.Target)
+	}
+
+	if !contains(metrics, rule.Metric) {
+		return fmt.Errorf("%v: rule %s has an unknown metric %s", ErrInvalidRule, rule.Name, rule.Metric)
+	}
+
+	if !contains([]string{">", ">=", "<", "<=", "==", "!="}, rule.Operator) {
+		return fmt.Errorf("%v: rule %s has an unknown operator %s", ErrInvalidRule, rule.Name, rule.Operator)
+	}
+
+	if rule.Severity != SeverityInfo && rule.Severity != SeverityWarning && rule.Severity != SeverityCritical {
+		return fmt.Errorf("%v: rule %s has an unknown severity %s", ErrInvalidRule, rule.Name, rule.Severity)
+	}
+
+	return nil
+}
+
+// match compares the provided value with the value of the rule.
+// If both values are numbers, we compare them as numbers. Otherwise only the '==' and '!=' operators can be used to compare the values as strings.
+func (r Rule) match(value string) bool {
+	actual, errActual := strconv.ParseFloat(value, 64)
+	expected, errExpected := strconv.ParseFloat(r.Value, 64)
+
+	if errActual == nil && errExpected == nil {
+		switch r.Operator {
+		case ">":
+			return actual > expected
+		case ">=":
+			return actual >= expected
+		case "<":
+			return actual < expected
+		case "<=":
+			return actual <= expected
+		case "==":
+			return actual == expected
+		case "!=":
+			return actual != expected
+		}
+	}
+
+	switch r.Operator {
+	case "==":
+		return strings.EqualFold(value, r.Value)
+	case "!=":
+		return !strings.EqualFold(value, r.Value)
+	}
+
+	return false
+}
+
+// contains returns true if the slice contains the provided value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,12 +21,33 @@ import (
 var (
 	// ErrConfigNotFound is thrown if there is not a confgiuration file for Kubernetes.
 	ErrConfigNotFound = errors.New("config not found")
+	// ErrUnknownWorkload is thrown if the selector for a workload could not be determined.
+	ErrUnknownWorkload = errors.New("unknown workload")
 )
 
+// EventSink is the interface for all sinks, which receive the events seen by the client.
+// A sink can be used to record all events while kubetop is running.
+type EventSink interface {
+	Send(events []Event)
+}
+
 // Client implements the our API client for Kubernetes.
 type Client struct {
 	config    *rest.Config
 	clientset *kubernetes.Clientset
+	sink      EventSink
+
+	eventsV1     bool
+	eventsV1Once sync.Once
+
+	owners       map[string]cachedOwner
+	ownersListed map[string]listedControllers
+	ownersMutex  sync.Mutex
+
+	summary summaryCache
+
+	podColumns  []customColumn
+	nodeColumns []customColumn
 }
 
 // getContainerMetrics returns the metrics for a container by the provided name from a slice of containers metrics.
@@ -82,6 +105,89 @@ func getNodeMetrics(name string, metrics []mev1beta1.NodeMetrics) *mev1beta1.Nod
 	return nil
 }
 
+// convertEvent converts an event returned by the Kubernetes API into our custom event structure.
+// The source, count and timestamps of an event are deprecated and often empty on newer clusters.
+// Then we use the values of the reporting controller, the series and the event time of the event instead.
+// The node is the host of the source or the reporting instance, when the event was reported by the kubelet.
+func convertEvent(event v1.Event) Event {
+	count := event.Count
+	firstTimestamp := event.FirstTimestamp.Time
+	lastTimestamp := event.LastTimestamp.Time
+
+	if event.Series != nil {
+		count = event.Series.Count
+		if event.Series.LastObservedTime.After(lastTimestamp) {
+			lastTimestamp = event.Series.LastObservedTime.Time
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+	if firstTimestamp.IsZero() {
+		firstTimestamp = event.EventTime.Time
+	}
+	if lastTimestamp.IsZero() {
+		lastTimestamp = event.EventTime.Time
+	}
+
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+
+	node := event.Source.Host
+	if node == "" && event.ReportingController == "kubelet" {
+		node = event.ReportingInstance
+	}
+	if node == "" && event.InvolvedObject.Kind == "Node" {
+		node = event.InvolvedObject.Name
+	}
+
+	return Event{
+		UID:                 string(event.UID),
+		ResourceVersion:     event.ResourceVersion,
+		Message:             event.Message,
+		Timestamp:           lastTimestamp.Unix(),
+		Count:               count,
+		Name:                event.Name,
+		Namespace:           event.Namespace,
+		Kind:                event.InvolvedObject.Kind,
+		Type:                event.Type,
+		Reason:              event.Reason,
+		Source:              source,
+		Node:                node,
+		FirstTimestamp:      firstTimestamp,
+		LastTimestamp:       lastTimestamp,
+		EventTime:           event.EventTime.Time,
+		ReportingController: event.ReportingController,
+		ReportingInstance:   event.ReportingInstance,
+		InvolvedObject: ObjectReference{
+			Kind:      event.InvolvedObject.Kind,
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+			UID:       string(event.InvolvedObject.UID),
+		},
+	}
+}
+
+// matchEvent returns true when the event matches the node, event type and reason of the provided filter.
+// The namespace is not checked, because it is already used to select the events from the Kubernetes API.
+func matchEvent(filter Filter, event Event) bool {
+	if filter.Node != "" && filter.Node != event.Node {
+		return false
+	}
+
+	if filter.EventType != "" && filter.EventType != event.Type {
+		return false
+	}
+
+	if filter.Reason != "" && filter.Reason != event.Reason {
+		return false
+	}
+
+	return true
+}
+
 // NewClient initialize our client for Kubernetes.
 // As first we check the 'kubeconfig' command-line flag which is passed as argument to our function.
 // If the flag is not provided we check the 'KUBECONFIG' environment variable.
@@ -112,11 +218,18 @@ func NewClient(kubeconfig string) (*Client, error) {
 	}
 
 	return &Client{
-		config:    config,
-		clientset: clientset,
+		config:       config,
+		clientset:    clientset,
+		owners:       make(map[string]cachedOwner),
+		ownersListed: make(map[string]listedControllers),
 	}, nil
 }
 
+// SetEventSink sets the sink, which receives all events returned by GetEvents and WatchEvents.
+func (c *Client) SetEventSink(sink EventSink) {
+	c.sink = sink
+}
+
 // GetClustername returns the name of the Kubernetes cluster.
 func (c *Client) GetClustername() string {
 	return c.config.Host
@@ -154,6 +267,59 @@ func (c *Client) GetNodes() ([]string, error) {
 	return nodes, nil
 }
 
+// GetWorkloadSelector returns the label selector for the pods of a workload.
+// The workload is identified by its kind, namespace and name, like it is referenced by the involved object of an event.
+func (c *Client) GetWorkloadSelector(kind, namespace, name string) (string, error) {
+	var selector *metav1.LabelSelector
+
+	switch kind {
+	case "Deployment":
+		deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = deployment.Spec.Selector
+	case "ReplicaSet":
+		replicaSet, err := c.clientset.AppsV1().ReplicaSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = replicaSet.Spec.Selector
+	case "StatefulSet":
+		statefulSet, err := c.clientset.AppsV1().StatefulSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = statefulSet.Spec.Selector
+	case "DaemonSet":
+		daemonSet, err := c.clientset.AppsV1().DaemonSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = daemonSet.Spec.Selector
+	case "Job":
+		job, err := c.clientset.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = job.Spec.Selector
+	case "ReplicationController":
+		replicationController, err := c.clientset.CoreV1().ReplicationControllers(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = &metav1.LabelSelector{MatchLabels: replicationController.Spec.Selector}
+	default:
+		return "", ErrUnknownWorkload
+	}
+
+	if selector == nil {
+		return "", ErrUnknownWorkload
+	}
+
+	return metav1.FormatLabelSelector(selector), nil
+}
+
 // GetNodesMetrics returns the metrics for all nodes.
 func (c *Client) GetNodesMetrics(sortorder Sort) ([]Node, error) {
 	var nodes []Node
@@ -176,10 +342,17 @@ func (c *Client) GetNodesMetrics(sortorder Sort) ([]Node, error) {
 		}
 	}
 
+	// Count the pods per node by the 'spec.nodeName' field of the pods.
+	// We list all pods with a single request instead of one request per node, because the nodes are also fetched for the cluster summary.
+	// If the pods could not be listed, we ignore the error, because we only display no number of pods.
+	podsCount := make(map[string]int)
+	if pods, err := c.clientset.CoreV1().Pods("").List(metav1.ListOptions{}); err == nil {
+		for _, pod := range pods.Items {
+			podsCount[pod.Spec.NodeName]++
+		}
+	}
+
 	// Iterate over each node and populate our custom node structure.
-	// To get the external and internal ip addess of a node we also call the node API endpoint per node.
-	// After that we select all pods for a node by the 'spec.nodeName' label of the pods.
-	// The pod data is not used yet, we only rely on the lenght of the slice for the number of pods per node.
 	for _, item := range nodesList.Items {
 		var memoryUsed, cpuUsed int64
 		nodeMetrics := getNodeMetrics(item.Name, nodeMetricsList.Items)
@@ -201,56 +374,38 @@ func (c *Client) GetNodesMetrics(sortorder Sort) ([]Node, error) {
 			}
 		}
 
-		var podsCount int
-		pods, err := c.clientset.CoreV1().Pods("").List(metav1.ListOptions{
-			FieldSelector: "spec.nodeName=" + item.Name,
-		})
-		if err == nil {
-			podsCount = len(pods.Items)
+		// Get the status of the node from the ready condition.
+		// If the condition is missing or the status is unknown the node status is 'Unknown'.
+		status := "Unknown"
+		for _, condition := range item.Status.Conditions {
+			if condition.Type == v1.NodeReady {
+				if condition.Status == v1.ConditionTrue {
+					status = "Ready"
+				} else if condition.Status == v1.ConditionFalse {
+					status = "NotReady"
+				}
+			}
 		}
 
 		nodes = append(nodes, Node{
-			Name:        item.Name,
-			PodsCount:   podsCount,
-			MemoryTotal: item.Status.Allocatable.Memory().Value(),
-			MemoryUsed:  memoryUsed,
-			CPUTotal:    item.Status.Allocatable.Cpu().MilliValue(),
-			CPUUsed:     cpuUsed,
-			ExternalIP:  externalIP,
-			InternalIP:  internalIP,
+			Name:         item.Name,
+			PodsCount:    podsCount[item.Name],
+			MemoryTotal:  item.Status.Allocatable.Memory().Value(),
+			MemoryUsed:   memoryUsed,
+			CPUTotal:     item.Status.Allocatable.Cpu().MilliValue(),
+			CPUUsed:      cpuUsed,
+			ExternalIP:   externalIP,
+			InternalIP:   internalIP,
+			Status:       status,
+			CreationDate: item.CreationTimestamp.Time,
+			Custom:       evaluateCustomColumns(c.nodeColumns, &item, item.Labels, item.Annotations),
 		})
 	}
 
+	c.recordSummaryNodes(nodes)
+
 	// Sort all our nodes by the provided sortorder.
-	if sortorder == SortCPUASC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].CPUUsed < nodes[j].CPUUsed
-		})
-	} else if sortorder == SortCPUDESC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].CPUUsed > nodes[j].CPUUsed
-		})
-	} else if sortorder == SortMemoryASC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].MemoryUsed < nodes[j].MemoryUsed
-		})
-	} else if sortorder == SortMemoryDESC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].MemoryUsed > nodes[j].MemoryUsed
-		})
-	} else if sortorder == SortName {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].Name < nodes[j].Name
-		})
-	} else if sortorder == SortPodsASC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].PodsCount < nodes[j].PodsCount
-		})
-	} else if sortorder == SortPodsDESC {
-		sort.SliceStable(nodes, func(i, j int) bool {
-			return nodes[i].PodsCount > nodes[j].PodsCount
-		})
-	}
+	sortNodes(nodes, sortorder, c.nodeColumns)
 
 	return nodes, nil
 }
@@ -264,6 +419,7 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 	// Get all the pods a second time from the Kubernetes API.
 	// This is needed because the metrics endpoint does not return all needed data.
 	// If the node filter is not empty we apply the field selector 'spec.nodeName' to only get pods on the specified node.
+	// If the selector filter is not empty we apply it as label selector, to only get the pods of a workload.
 	if filter.Node == "" {
 		options = metav1.ListOptions{}
 	} else {
@@ -271,6 +427,7 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 			FieldSelector: "spec.nodeName=" + filter.Node,
 		}
 	}
+	options.LabelSelector = filter.Selector
 
 	podsList, err := c.clientset.CoreV1().Pods(filter.Namespace).List(options)
 	if err != nil {
@@ -323,10 +480,12 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 		// We set the status to running and only change this status if one container has an state which is not running.
 		// The number of restarts represents the sum of the individual restarts of each container in a pod.
 		// Last but not least we count how many containers in a pod are ready to serve requests.
+		// We also remember the reason, why a container was terminated the last time (e.g. 'OOMKilled').
 		status := "Running"
 		statusGeneral := 2
 		statusDefined := false
 		var ready, restarts int64
+		var lastTerminationReason string
 
 		for _, container := range item.Status.ContainerStatuses {
 			if container.Ready {
@@ -335,6 +494,10 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 
 			restarts = restarts + int64(container.RestartCount)
 
+			if lastTerminationReason == "" && container.LastTerminationState.Terminated != nil {
+				lastTerminationReason = container.LastTerminationState.Terminated.Reason
+			}
+
 			if !statusDefined {
 				if container.State.Waiting != nil {
 					status = container.State.Waiting.Reason
@@ -348,6 +511,11 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 			}
 		}
 
+		var controlledBy []string
+		for _, ownerReference := range item.OwnerReferences {
+			controlledBy = append(controlledBy, ownerReference.Kind+"/"+ownerReference.Name)
+		}
+
 		// Add the pod to our slice of pods whenn the status matchs the specified status in the filter.
 		// If the status in the filter is 10 then all pods are added.
 		if filter.Status == 10 || filter.Status == statusGeneral {
@@ -366,51 +534,24 @@ func (c *Client) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
 				Status:                  status,
 				StatusGeneral:           statusGeneral,
 				Restarts:                restarts,
+				LastTerminationReason:   lastTerminationReason,
+				QOSClass:                string(item.Status.QOSClass),
+				Labels:                  item.Labels,
+				ControlledBy:            controlledBy,
 				CreationDate:            item.CreationTimestamp.Time,
 				IP:                      item.Status.PodIP,
+				Custom:                  evaluateCustomColumns(c.podColumns, &item, item.Labels, item.Annotations),
 			})
 		}
 	}
 
-	// Sort all our pods by the provided sortorder.
-	if sortorder == SortCPUASC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].CPU < pods[j].CPU
-		})
-	} else if sortorder == SortCPUDESC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].CPU > pods[j].CPU
-		})
-	} else if sortorder == SortMemoryASC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Memory < pods[j].Memory
-		})
-	} else if sortorder == SortMemoryDESC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Memory > pods[j].Memory
-		})
-	} else if sortorder == SortName {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Name < pods[j].Name
-		})
-	} else if sortorder == SortNamespace {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Namespace < pods[j].Namespace
-		})
-	} else if sortorder == SortRestartsASC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Restarts < pods[j].Restarts
-		})
-	} else if sortorder == SortRestartsDESC {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].Restarts > pods[j].Restarts
-		})
-	} else if sortorder == SortStatus {
-		sort.SliceStable(pods, func(i, j int) bool {
-			return pods[i].StatusGeneral < pods[j].StatusGeneral
-		})
+	if isUnfiltered(filter) {
+		c.recordSummaryPods(pods)
 	}
 
+	// Sort all our pods by the provided sortorder.
+	sortPods(pods, sortorder, c.podColumns)
+
 	return pods, nil
 }
 
@@ -428,16 +569,14 @@ func (c *Client) GetPod(name, namespace string, selectedContainer int) (*Pod, er
 
 	// Get the events for a pod.
 	// cURL Example: curl http://localhost:8001/api/v1/namespaces/kube-system/events?fieldSelector=involvedObject.name=kube-proxy-tfpcb
+	// We also match the namespace and uid of the involved object, so that we do not show events of an older pod with the same name.
 	// We ignore an error during the API call, because we only lose the events for the pod.
-	podEvents, err := c.clientset.CoreV1().Events(namespace).List(metav1.ListOptions{
-		FieldSelector: "involvedObject.name=" + name,
+	podEvents, _, err := c.listEvents(namespace, metav1.ListOptions{
+		FieldSelector: "involvedObject.name=" + name + ",involvedObject.namespace=" + namespace + ",involvedObject.uid=" + string(pod.UID),
 	})
-	if err == nil && podEvents != nil {
-		for _, event := range podEvents.Items {
-			events = append(events, Event{
-				Message:   event.Message,
-				Timestamp: event.LastTimestamp.Unix(),
-			})
+	if err == nil {
+		for _, event := range podEvents {
+			events = append(events, convertEvent(event))
 		}
 	}
 
@@ -472,13 +611,26 @@ func (c *Client) GetPod(name, namespace string, selectedContainer int) (*Pod, er
 			memory = containerMetrics.Usage.Memory().Value()
 		}
 
-		// Get the number of restarts and the status of a container.
+		// Get the number of restarts, the status and the last termination state of a container.
+		// The last termination state is important to find containers which were OOMKilled, but are running again.
 		var restarts int32
+		var lastTerminationState *ContainerTermination
 		statuses := getContainerStatus(container.Name, pod.Status.ContainerStatuses)
 		status := "-"
 
 		if statuses != nil {
 			restarts = statuses.RestartCount
+
+			if terminated := statuses.LastTerminationState.Terminated; terminated != nil {
+				lastTerminationState = &ContainerTermination{
+					Reason:     terminated.Reason,
+					Message:    terminated.Message,
+					ExitCode:   terminated.ExitCode,
+					Signal:     terminated.Signal,
+					StartedAt:  terminated.StartedAt.Time,
+					FinishedAt: terminated.FinishedAt.Time,
+				}
+			}
 			status = "Running"
 			statusDefined := false
 
@@ -503,6 +655,8 @@ func (c *Client) GetPod(name, namespace string, selectedContainer int) (*Pod, er
 			Memory:    memory,
 			MemoryMax: container.Resources.Limits.Memory().Value(),
 			MemoryMin: container.Resources.Requests.Memory().Value(),
+
+			LastTerminationState: lastTerminationState,
 		})
 	}
 
@@ -546,75 +700,323 @@ func (c *Client) GetPod(name, namespace string, selectedContainer int) (*Pod, er
 
 // GetEvents returns events.
 func (c *Client) GetEvents(filter Filter, sortorder Sort) ([]Event, error) {
+	events, _, err := c.ListEvents(filter, sortorder)
+	return events, err
+}
+
+// ListEvents returns events and the resource version of the list.
+// The resource version can be passed to WatchEvents, so that the watch starts exactly after the returned events and no event is missed.
+func (c *Client) ListEvents(filter Filter, sortorder Sort) ([]Event, string, error) {
 	var events []Event
 
-	eventsList, err := c.clientset.CoreV1().Events(filter.Namespace).List(metav1.ListOptions{})
+	eventsList, resourceVersion, err := c.listEvents(filter.Namespace, metav1.ListOptions{})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	for _, event := range eventsList.Items {
-		if filter.Node == "" || filter.Node == event.Source.Host {
-			if filter.EventType == "" || filter.EventType == event.Type {
-				events = append(events, Event{
-					UID:       string(event.UID),
-					Message:   event.Message,
-					Timestamp: event.LastTimestamp.Unix(),
-					Count:     event.Count,
-					Name:      event.Name,
-					Namespace: event.Namespace,
-					Kind:      event.Kind,
-					Type:      event.Type,
-					Reason:    event.Reason,
-					Source:    event.Source.Component,
-					Node:      event.Source.Host,
-				})
-			}
+	// All events are send to the sink, also the events which are not matching the filter.
+	var seen []Event
+	for _, item := range eventsList {
+		event := convertEvent(item)
+		seen = append(seen, event)
+		if matchEvent(filter, event) {
+			events = append(events, event)
 		}
 	}
 
-	// Sort all our events by the provided sortorder.
-	if sortorder == SortName {
-		sort.SliceStable(events, func(i, j int) bool {
-			return events[i].Name < events[j].Name
-		})
-	} else if sortorder == SortNamespace {
-		sort.SliceStable(events, func(i, j int) bool {
-			return events[i].Namespace < events[j].Namespace
-		})
-	} else if sortorder == SortTimeASC {
-		sort.SliceStable(events, func(i, j int) bool {
-			return events[i].Timestamp < events[j].Timestamp
-		})
-	} else if sortorder == SortTimeDESC {
-		sort.SliceStable(events, func(i, j int) bool {
-			return events[i].Timestamp > events[j].Timestamp
-		})
+	if c.sink != nil {
+		c.sink.Send(seen)
 	}
 
-	return events, nil
+	if filter.Namespace == "" {
+		c.recordSummaryEvents(seen)
+	}
+
+	// Sort all our events by the provided sortorder.
+	sortEvents(events, sortorder)
+
+	return events, resourceVersion, nil
 }
 
 // GetEvent returns a single event.
 func (c *Client) GetEvent(name, namespace string) Event {
-	event, err := c.clientset.CoreV1().Events(namespace).Get(name, metav1.GetOptions{})
+	event, err := c.getEvent(name, namespace)
 	if err != nil {
 		return Event{}
 	}
 
-	return Event{
-		UID:            string(event.UID),
-		Message:        event.Message,
-		Timestamp:      event.LastTimestamp.Unix(),
-		Count:          event.Count,
-		Name:           event.Name,
-		Namespace:      event.Namespace,
-		Kind:           event.Kind,
-		Type:           event.Type,
-		Reason:         event.Reason,
-		Source:         event.Source.Component,
-		Node:           event.Source.Host,
-		FirstTimestamp: event.FirstTimestamp.Time,
-		LastTimestamp:  event.LastTimestamp.Time,
+	return convertEvent(*event)
+}
+
+// GetEventReasons returns a slice of all reasons of the events in the provided namespace.
+func (c *Client) GetEventReasons(namespace string) ([]string, error) {
+	eventsList, _, err := c.listEvents(namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	var reasons []string
+	for _, event := range eventsList {
+		if event.Reason != "" && !found[event.Reason] {
+			found[event.Reason] = true
+			reasons = append(reasons, event.Reason)
+		}
+	}
+
+	sort.Strings(reasons)
+	return append([]string{"-"}, reasons...), nil
+}
+
+// GroupEvents groups the provided events by the kind of the involved object, the reason and the owner workload of the involved object.
+// For each group we sum up the counts of all events, determine when an event of the group was seen the first and the last time and use the message of the last event as sample message.
+// The groups are sorted by the time when an event of the group was seen the last time.
+func (c *Client) GroupEvents(events []Event) []EventGroup {
+	listed := c.listControllers(events)
+
+	return groupEvents(events, func(ref ObjectReference) ObjectReference {
+		owner, _ := c.getOwner(ref, listed)
+		return owner
+	})
+}
+
+// groupEvents groups the provided events. The owner workload of the involved object of an event is returned by the provided getOwner function.
+func groupEvents(events []Event, getOwner func(ref ObjectReference) ObjectReference) []EventGroup {
+	var groups []EventGroup
+	indexes := make(map[string]int)
+
+	for _, event := range events {
+		owner := getOwner(event.InvolvedObject)
+		key := event.Namespace + "/" + event.InvolvedObject.Kind + "/" + event.Reason + "/" + owner.Kind + "/" + owner.Name
+
+		index, ok := indexes[key]
+		if !ok {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, EventGroup{
+				Key:            key,
+				Kind:           event.InvolvedObject.Kind,
+				Reason:         event.Reason,
+				Owner:          owner,
+				Namespace:      event.Namespace,
+				Type:           event.Type,
+				FirstTimestamp: event.FirstTimestamp,
+				LastTimestamp:  event.LastTimestamp,
+			})
+		}
+
+		group := &groups[index]
+		group.Count = group.Count + event.Count
+		group.Events = append(group.Events, event)
+
+		if event.Type == "Warning" {
+			group.Type = event.Type
+		}
+
+		if event.FirstTimestamp.Before(group.FirstTimestamp) {
+			group.FirstTimestamp = event.FirstTimestamp
+		}
+
+		if !event.LastTimestamp.Before(group.LastTimestamp) {
+			group.LastTimestamp = event.LastTimestamp
+			group.Message = event.Message
+		}
 	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].LastTimestamp.After(groups[j].LastTimestamp)
+	})
+
+	return groups
+}
+
+// controllers contains the controller references of the pods, replica sets and jobs of the namespaces which were listed to determine the owners of objects.
+// The references are keyed by the kind, namespace and name of the object; objects without a controller have a nil reference.
+// Failed contains the kinds and namespaces (e.g. 'Pod/default'), which could not be listed.
+type controllers struct {
+	refs   map[string]*metav1.OwnerReference
+	failed map[string]bool
+}
+
+// listedControllers are the controllers of one namespace and the time when they were listed.
+type listedControllers struct {
+	controllers
+	time time.Time
+}
+
+// cachedOwner is the owner of an object in the cache of owners and the time when it was used the last time.
+type cachedOwner struct {
+	owner ObjectReference
+	used  time.Time
+}
+
+const (
+	// ownerCacheTTL is the time after which an owner is removed from the cache, when it was not used to group events.
+	// This is the case for objects, which were deleted and whose events are not returned by the Kubernetes API anymore.
+	ownerCacheTTL = 10 * time.Minute
+	// ownerListMaxAge is the time for which the listed controllers of a namespace are reused.
+	// The events are grouped for the current view and the views of all tabs in the background, so that we do not list the same namespace for each of them.
+	ownerListMaxAge = 10 * time.Second
+)
+
+// ownerKinds are the kinds of objects, for which we follow the controller reference to the owner workload.
+var ownerKinds = map[string]bool{"Pod": true, "ReplicaSet": true, "Job": true}
+
+// ownerCacheKey returns the key of an object in the cache of owners. We use the uid of the object, because the owner of an object never changes.
+func ownerCacheKey(ref ObjectReference) string {
+	if ref.UID != "" {
+		return ref.UID
+	}
+
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+// evictOwners removes all owners, which were not used within the ttl of the cache, and all listed controllers, which are older than the maximum age.
+// The mutex of the cache must be held by the caller.
+func (c *Client) evictOwners(now time.Time) {
+	for key, cached := range c.owners {
+		if now.Sub(cached.used) > ownerCacheTTL {
+			delete(c.owners, key)
+		}
+	}
+
+	for namespace, listed := range c.ownersListed {
+		if now.Sub(listed.time) > ownerListMaxAge {
+			delete(c.ownersListed, namespace)
+		}
+	}
+}
+
+// listControllers returns the pods, replica sets and jobs of all namespaces, which contain an involved object of the provided events, whose owner is not cached yet.
+// We list all objects of a namespace at once instead of getting every object on its own, because the events are grouped on every refresh. A namespace is only listed again, when the last listing is older than the maximum age.
+func (c *Client) listControllers(events []Event) controllers {
+	result := controllers{
+		refs:   make(map[string]*metav1.OwnerReference),
+		failed: make(map[string]bool),
+	}
+
+	namespaces := make(map[string]bool)
+	c.ownersMutex.Lock()
+	c.evictOwners(time.Now())
+	for _, event := range events {
+		ref := event.InvolvedObject
+		if _, ok := c.owners[ownerCacheKey(ref)]; !ok && ownerKinds[ref.Kind] {
+			if listed, ok := c.ownersListed[ref.Namespace]; ok {
+				result.merge(listed.controllers)
+			} else {
+				namespaces[ref.Namespace] = true
+			}
+		}
+	}
+	c.ownersMutex.Unlock()
+
+	for namespace := range namespaces {
+		listed := c.listNamespaceControllers(namespace)
+		result.merge(listed)
+
+		c.ownersMutex.Lock()
+		c.ownersListed[namespace] = listedControllers{listed, time.Now()}
+		c.ownersMutex.Unlock()
+	}
+
+	return result
+}
+
+// listNamespaceControllers lists the pods, replica sets and jobs of the provided namespace.
+func (c *Client) listNamespaceControllers(namespace string) controllers {
+	result := controllers{
+		refs:   make(map[string]*metav1.OwnerReference),
+		failed: make(map[string]bool),
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err == nil {
+		for i := range pods.Items {
+			result.refs["Pod/"+namespace+"/"+pods.Items[i].Name] = metav1.GetControllerOf(&pods.Items[i])
+		}
+	} else {
+		result.failed["Pod/"+namespace] = true
+	}
+
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(metav1.ListOptions{})
+	if err == nil {
+		for i := range replicaSets.Items {
+			result.refs["ReplicaSet/"+namespace+"/"+replicaSets.Items[i].Name] = metav1.GetControllerOf(&replicaSets.Items[i])
+		}
+	} else {
+		result.failed["ReplicaSet/"+namespace] = true
+	}
+
+	jobs, err := c.clientset.BatchV1().Jobs(namespace).List(metav1.ListOptions{})
+	if err == nil {
+		for i := range jobs.Items {
+			result.refs["Job/"+namespace+"/"+jobs.Items[i].Name] = metav1.GetControllerOf(&jobs.Items[i])
+		}
+	} else {
+		result.failed["Job/"+namespace] = true
+	}
+
+	return result
+}
+
+// merge adds the references and the failed kinds of the provided controllers.
+func (c controllers) merge(other controllers) {
+	for key, ref := range other.refs {
+		c.refs[key] = ref
+	}
+
+	for key := range other.failed {
+		c.failed[key] = true
+	}
+}
+
+// getOwner returns the workload which owns the provided object.
+// For pods, replica sets and jobs we follow the controller references from the listed controllers up to the deployment or cron job, for all other objects the object itself is returned.
+// If the object was already deleted, we can not determine the owner and also return the object itself.
+// The second return value is false, when the owner could not be determined because the objects could not be listed. Such owners are not cached, so that we try it again on the next refresh.
+func (c *Client) getOwner(ref ObjectReference, listed controllers) (ObjectReference, bool) {
+	cacheKey := ownerCacheKey(ref)
+
+	c.ownersMutex.Lock()
+	cached, ok := c.owners[cacheKey]
+	if ok {
+		c.owners[cacheKey] = cachedOwner{cached.owner, time.Now()}
+	}
+	c.ownersMutex.Unlock()
+	if ok {
+		return cached.owner, true
+	}
+
+	if !ownerKinds[ref.Kind] {
+		return ref, true
+	}
+
+	if listed.failed[ref.Kind+"/"+ref.Namespace] {
+		return ref, false
+	}
+
+	owner := ref
+	cacheable := true
+	controllerRef, found := listed.refs[ref.Kind+"/"+ref.Namespace+"/"+ref.Name]
+	if !found && ref.UID == "" {
+		// Without an uid we can not be sure, that an object with the same name is not created later.
+		cacheable = false
+	}
+
+	if controllerRef != nil {
+		owner, cacheable = c.getOwner(ObjectReference{
+			Kind:      controllerRef.Kind,
+			Namespace: ref.Namespace,
+			Name:      controllerRef.Name,
+			UID:       string(controllerRef.UID),
+		}, listed)
+	}
+
+	if cacheable {
+		c.ownersMutex.Lock()
+		c.owners[cacheKey] = cachedOwner{owner, time.Now()}
+		c.ownersMutex.Unlock()
+	}
+
+	return owner, cacheable
 }
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var (
+	// ErrInvalidCustomColumn is thrown if a custom column has no name or not exactly one expression.
+	ErrInvalidCustomColumn = errors.New("invalid custom column")
+	// ErrDuplicatedCustomColumn is thrown if the name of a custom column is already used by another custom column or by a built-in column.
+	ErrDuplicatedCustomColumn = errors.New("duplicated custom column")
+)
+
+// CustomColumn represents an additional column for the pods or nodes view.
+// The value of a custom column is the value of a label, the value of an annotation or the result of a JSONPath expression against the raw Kubernetes object.
+// Exactly one of these expressions must be set.
+type CustomColumn struct {
+	Name       string `json:"name"`
+	Header     string `json:"header"`
+	Width      int    `json:"width"`
+	Label      string `json:"label"`
+	Annotation string `json:"annotation"`
+	JSONPath   string `json:"jsonPath"`
+}
+
+// customColumn is a custom column with the normalized JSONPath expression.
+// We do not keep the parsed expression, because the parser keeps its state while the expression is executed and the columns are evaluated from multiple goroutines.
+type customColumn struct {
+	CustomColumn
+	expression string
+}
+
+// SetCustomColumns sets the custom columns for pods and nodes.
+// The JSONPath expressions are parsed once, so that an invalid expression is reported when kubetop is started.
+func (c *Client) SetCustomColumns(pods, nodes []CustomColumn) error {
+	var err error
+
+	c.podColumns, err = parseCustomColumns(pods, isPodColumn)
+	if err != nil {
+		return err
+	}
+
+	c.nodeColumns, err = parseCustomColumns(nodes, isNodeColumn)
+	return err
+}
+
+// isPodColumn and isNodeColumn return true if the provided name is the name of a built-in column of the pods or nodes view.
+// The built-in columns are the columns for which a comparator exists, because all built-in columns can be sorted.
+func isPodColumn(name string) bool {
+	_, ok := podComparators[name]
+	return ok
+}
+
+func isNodeColumn(name string) bool {
+	_, ok := nodeComparators[name]
+	return ok
+}
+
+// PodCustomColumns returns the custom columns for pods.
+func (c *Client) PodCustomColumns() []CustomColumn {
+	return unparseCustomColumns(c.podColumns)
+}
+
+// NodeCustomColumns returns the custom columns for nodes.
+func (c *Client) NodeCustomColumns() []CustomColumn {
+	return unparseCustomColumns(c.nodeColumns)
+}
+
+// customColumnHeader returns the header of a custom column. If no header is set, we use the name in upper case.
+func customColumnHeader(column CustomColumn) string {
+	if column.Header != "" {
+		return column.Header
+	}
+
+	return strings.ToUpper(column.Name)
+}
+
+// parseCustomColumns validates the custom columns and parses the JSONPath expressions.
+// The JSONPath expressions can be written with or without the surrounding braces and the leading dot (e.g. 'spec.nodeName').
+// The names must be unique and must not be the name of a built-in column, because the columns are identified by their name in the configuration file, the sort list and the column picker.
+func parseCustomColumns(columns []CustomColumn, builtin func(name string) bool) ([]customColumn, error) {
+	var parsed []customColumn
+	names := make(map[string]bool)
+
+	for _, column := range columns {
+		expressions := 0
+		for _, expression := range []string{column.Label, column.Annotation, column.JSONPath} {
+			if expression != "" {
+				expressions++
+			}
+		}
+
+		if column.Name == "" || expressions != 1 {
+			return nil, fmt.Errorf("%v: %s", ErrInvalidCustomColumn, column.Name)
+		}
+
+		if names[column.Name] || builtin(column.Name) {
+			return nil, fmt.Errorf("%v: %s", ErrDuplicatedCustomColumn, column.Name)
+		}
+		names[column.Name] = true
+
+		if column.Header == "" {
+			column.Header = customColumnHeader(column)
+		}
+
+		var expression string
+		if column.JSONPath != "" {
+			expression = column.JSONPath
+			if !strings.HasPrefix(expression, "{") {
+				if !strings.HasPrefix(expression, ".") && !strings.HasPrefix(expression, "$") {
+					expression = "." + expression
+				}
+				expression = "{" + expression + "}"
+			}
+
+			if _, err := newJSONPath(column.Name, expression); err != nil {
+				return nil, fmt.Errorf("%v: %s: %s", ErrInvalidCustomColumn, column.Name, err.Error())
+			}
+		}
+
+		parsed = append(parsed, customColumn{column, expression})
+	}
+
+	return parsed, nil
+}
+
+// newJSONPath returns a new parser for the provided JSONPath expression. Missing keys are allowed, so that the value is empty when a field is not set.
+func newJSONPath(name, expression string) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(name)
+	jp.AllowMissingKeys(true)
+	if err := jp.Parse(expression); err != nil {
+		return nil, err
+	}
+
+	return jp, nil
+}
+
+// unparseCustomColumns returns the custom columns without the parsed JSONPath expressions.
+func unparseCustomColumns(columns []customColumn) []CustomColumn {
+	var unparsed []CustomColumn
+	for _, column := range columns {
+		unparsed = append(unparsed, column.CustomColumn)
+	}
+
+	return unparsed
+}
+
+// evaluateCustomColumns returns the values of all custom columns for an object.
+// The object is only converted to an unstructured object, when a JSONPath expression is used, so that the field names in the expressions are the same as in the YAML representation of the object.
+// If an expression could not be evaluated, the value of the column is empty. The JSONPath expressions are parsed for each object, because a parser can not be used concurrently.
+func evaluateCustomColumns(columns []customColumn, obj interface{}, labels, annotations map[string]string) []string {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var unstructured map[string]interface{}
+	values := make([]string, len(columns))
+
+	for index, column := range columns {
+		switch {
+		case column.Label != "":
+			values[index] = labels[column.Label]
+		case column.Annotation != "":
+			values[index] = annotations[column.Annotation]
+		case column.expression != "":
+			if unstructured == nil {
+				var err error
+				unstructured, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				if err != nil {
+					continue
+				}
+			}
+
+			jp, err := newJSONPath(column.Name, column.expression)
+			if err != nil {
+				continue
+			}
+
+			var buf bytes.Buffer
+			if err := jp.Execute(&buf, unstructured); err == nil {
+				values[index] = buf.String()
+			}
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		columns    []CustomColumn
+		wantHeader string
+		wantErr    bool
+	}{
+		{"label", []CustomColumn{{Name: "team", Label: "team"}}, "TEAM", false},
+		{"annotation with header", []CustomColumn{{Name: "maintainer", Header: "OWNED BY", Annotation: "example.com/owner"}}, "OWNED BY", false},
+		{"jsonpath without braces", []CustomColumn{{Name: "scheduledOn", JSONPath: "spec.nodeName"}}, "SCHEDULEDON", false},
+		{"jsonpath with leading dot", []CustomColumn{{Name: "scheduledOn", JSONPath: ".spec.nodeName"}}, "SCHEDULEDON", false},
+		{"jsonpath with braces", []CustomColumn{{Name: "scheduledOn", JSONPath: "{.spec.nodeName}"}}, "SCHEDULEDON", false},
+		{"missing name", []CustomColumn{{Label: "team"}}, "", true},
+		{"missing expression", []CustomColumn{{Name: "team"}}, "", true},
+		{"multiple expressions", []CustomColumn{{Name: "team", Label: "team", Annotation: "team"}}, "", true},
+		{"invalid jsonpath", []CustomColumn{{Name: "scheduledOn", JSONPath: "{.spec.nodeName"}}, "", true},
+		{"duplicated name", []CustomColumn{{Name: "team", Label: "team"}, {Name: "team", Annotation: "team"}}, "", true},
+		{"name of built-in column", []CustomColumn{{Name: "node", JSONPath: "spec.nodeName"}}, "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseCustomColumns(tt.columns, isPodColumn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(parsed) != 1 {
+				t.Fatalf("expected one parsed column, got %d", len(parsed))
+			}
+			if parsed[0].Header != tt.wantHeader {
+				t.Errorf("expected header %q, got %q", tt.wantHeader, parsed[0].Header)
+			}
+			if (parsed[0].expression != "") != (tt.columns[0].JSONPath != "") {
+				t.Errorf("expected expression only for jsonpath columns")
+			}
+		})
+	}
+}
+
+func TestEvaluateCustomColumnsConcurrently(t *testing.T) {
+	columns, err := parseCustomColumns([]CustomColumn{
+		{Name: "team", Label: "team"},
+		{Name: "scheduledOn", JSONPath: "spec.nodeName"},
+		{Name: "images", JSONPath: "{range .spec.containers[*]}{.image} {end}"},
+	}, isPodColumn)
+	if err != nil {
+		t.Fatalf("could not parse custom columns: %v", err)
+	}
+
+	// The columns are evaluated by the refresh of the view and by the background refresh of the cluster summary at the same time.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				node := fmt.Sprintf("node-%d-%d", i, j)
+				pod := corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod", Labels: map[string]string{"team": "payments"}},
+					Spec:       corev1.PodSpec{NodeName: node, Containers: []corev1.Container{{Image: "nginx"}, {Image: "envoy"}}},
+				}
+
+				values := evaluateCustomColumns(columns, &pod, pod.Labels, pod.Annotations)
+				if len(values) != 3 || values[0] != "payments" || values[1] != node || values[2] != "nginx envoy " {
+					t.Errorf("unexpected values %q for %s", values, node)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// eventsV1GroupVersion is the group version of the events.k8s.io API.
+	// The API is not supported by the client-go version we use, so that we have to call it via the REST client.
+	eventsV1GroupVersion = "events.k8s.io/v1"
+)
+
+// eventV1 represents an event of the events.k8s.io/v1 API.
+// We only add the fields, which are needed to populate our custom event structure.
+type eventV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	EventTime                metav1.MicroTime   `json:"eventTime,omitempty"`
+	Series                   *v1.EventSeries    `json:"series,omitempty"`
+	ReportingController      string             `json:"reportingController,omitempty"`
+	ReportingInstance        string             `json:"reportingInstance,omitempty"`
+	Action                   string             `json:"action,omitempty"`
+	Reason                   string             `json:"reason,omitempty"`
+	Regarding                v1.ObjectReference `json:"regarding,omitempty"`
+	Note                     string             `json:"note,omitempty"`
+	Type                     string             `json:"type,omitempty"`
+	DeprecatedSource         v1.EventSource     `json:"deprecatedSource,omitempty"`
+	DeprecatedFirstTimestamp metav1.Time        `json:"deprecatedFirstTimestamp,omitempty"`
+	DeprecatedLastTimestamp  metav1.Time        `json:"deprecatedLastTimestamp,omitempty"`
+	DeprecatedCount          int32              `json:"deprecatedCount,omitempty"`
+}
+
+// eventListV1 represents a list of events of the events.k8s.io/v1 API.
+type eventListV1 struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []eventV1 `json:"items"`
+}
+
+// watchEventV1 represents a single event of a watch for the events.k8s.io/v1 API.
+type watchEventV1 struct {
+	Type   watch.EventType `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// eventsV1Decoder decodes the stream of a watch for the events.k8s.io/v1 API.
+// The events are converted to core/v1 events, so that the watch can be handled like a watch for the core/v1 API.
+type eventsV1Decoder struct {
+	stream  io.ReadCloser
+	decoder *json.Decoder
+}
+
+// Decode returns the next event from the watch stream.
+func (d *eventsV1Decoder) Decode() (watch.EventType, runtime.Object, error) {
+	var result watchEventV1
+	if err := d.decoder.Decode(&result); err != nil {
+		return "", nil, err
+	}
+
+	if result.Type == watch.Error {
+		var status metav1.Status
+		if err := json.Unmarshal(result.Object, &status); err != nil {
+			return "", nil, err
+		}
+
+		return result.Type, &status, nil
+	}
+
+	var event eventV1
+	if err := json.Unmarshal(result.Object, &event); err != nil {
+		return "", nil, err
+	}
+
+	return result.Type, convertEventV1(event), nil
+}
+
+// Close closes the watch stream.
+func (d *eventsV1Decoder) Close() {
+	d.stream.Close()
+}
+
+// convertEventV1 converts an event of the events.k8s.io/v1 API to an event of the core/v1 API.
+// The deprecated fields of the events.k8s.io/v1 API contain the values of the corresponding fields of the core/v1 API.
+func convertEventV1(event eventV1) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:          event.ObjectMeta,
+		InvolvedObject:      event.Regarding,
+		Reason:              event.Reason,
+		Message:             event.Note,
+		Source:              event.DeprecatedSource,
+		FirstTimestamp:      event.DeprecatedFirstTimestamp,
+		LastTimestamp:       event.DeprecatedLastTimestamp,
+		Count:               event.DeprecatedCount,
+		Type:                event.Type,
+		EventTime:           event.EventTime,
+		Series:              event.Series,
+		Action:              event.Action,
+		ReportingController: event.ReportingController,
+		ReportingInstance:   event.ReportingInstance,
+	}
+}
+
+// eventsV1Path returns the path for the events of the events.k8s.io/v1 API in the provided namespace.
+func eventsV1Path(namespace string) string {
+	if namespace == "" {
+		return "apis/" + eventsV1GroupVersion + "/events"
+	}
+
+	return "apis/" + eventsV1GroupVersion + "/namespaces/" + namespace + "/events"
+}
+
+// eventsV1FieldSelector translates a field selector for the core/v1 API to a field selector for the events.k8s.io/v1 API.
+func eventsV1FieldSelector(fieldSelector string) string {
+	return strings.Replace(fieldSelector, "involvedObject.", "regarding.", -1)
+}
+
+// useEventsV1 returns true if the events.k8s.io/v1 API is served by the Kubernetes API server.
+// We only check this once via the discovery API, because the served APIs do not change while kubetop is running.
+func (c *Client) useEventsV1() bool {
+	c.eventsV1Once.Do(func() {
+		resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(eventsV1GroupVersion)
+		if err != nil {
+			return
+		}
+
+		for _, resource := range resources.APIResources {
+			if resource.Name == "events" {
+				c.eventsV1 = true
+			}
+		}
+	})
+
+	return c.eventsV1
+}
+
+// listEvents returns the events in the provided namespace, which match the provided list options and the resource version of the list.
+// If the events.k8s.io/v1 API is available we use it, otherwise we fall back to the core/v1 API.
+// The field selector in the list options must use the field names of the core/v1 API.
+func (c *Client) listEvents(namespace string, options metav1.ListOptions) ([]v1.Event, string, error) {
+	if !c.useEventsV1() {
+		eventsList, err := c.clientset.CoreV1().Events(namespace).List(options)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return eventsList.Items, eventsList.ResourceVersion, nil
+	}
+
+	request := c.clientset.RESTClient().Get().AbsPath(eventsV1Path(namespace))
+	if options.FieldSelector != "" {
+		request = request.Param("fieldSelector", eventsV1FieldSelector(options.FieldSelector))
+	}
+	if options.Limit > 0 {
+		request = request.Param("limit", strconv.FormatInt(options.Limit, 10))
+	}
+
+	data, err := request.DoRaw()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var eventsList eventListV1
+	err = json.Unmarshal(data, &eventsList)
+	if err != nil {
+		return nil, "", err
+	}
+
+	events := make([]v1.Event, 0, len(eventsList.Items))
+	for _, event := range eventsList.Items {
+		events = append(events, *convertEventV1(event))
+	}
+
+	return events, eventsList.ResourceVersion, nil
+}
+
+// getEvent returns a single event from the events.k8s.io/v1 or the core/v1 API.
+func (c *Client) getEvent(name, namespace string) (*v1.Event, error) {
+	if !c.useEventsV1() {
+		return c.clientset.CoreV1().Events(namespace).Get(name, metav1.GetOptions{})
+	}
+
+	data, err := c.clientset.RESTClient().Get().AbsPath(eventsV1Path(namespace), name).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	var event eventV1
+	err = json.Unmarshal(data, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertEventV1(event), nil
+}
+
+// watchEventsAPI starts a watch for the events in the provided namespace at the provided resource version.
+// For the events.k8s.io/v1 API the events are converted to core/v1 events, so that the caller can handle both APIs in the same way.
+func (c *Client) watchEventsAPI(namespace, resourceVersion string) (watch.Interface, error) {
+	if !c.useEventsV1() {
+		return c.clientset.CoreV1().Events(namespace).Watch(metav1.ListOptions{
+			ResourceVersion: resourceVersion,
+		})
+	}
+
+	stream, err := c.clientset.RESTClient().Get().AbsPath(eventsV1Path(namespace)).Param("watch", "true").Param("resourceVersion", resourceVersion).Stream()
+	if err != nil {
+		return nil, err
+	}
+
+	return watch.NewStreamWatcher(&eventsV1Decoder{
+		stream:  stream,
+		decoder: json.NewDecoder(stream),
+	}), nil
+}
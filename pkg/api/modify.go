@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	// ErrInvalidLabel is thrown if a label is neither in the format 'key=value' nor in the format 'key-'.
+	ErrInvalidLabel = errors.New("invalid label")
+)
+
+// ParseLabels parses the provided labels into the labels for a merge patch.
+// A label in the format 'key=value' sets the label, a label in the format 'key-' removes the label, like it is done by 'kubectl label'.
+// Labels which should be removed have a nil value.
+func ParseLabels(labels []string) (map[string]*string, error) {
+	parsed := make(map[string]*string)
+
+	for _, label := range labels {
+		if strings.HasSuffix(label, "-") && !strings.Contains(label, "=") {
+			parsed[strings.TrimSuffix(label, "-")] = nil
+			continue
+		}
+
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%v: %s", ErrInvalidLabel, label)
+		}
+
+		value := parts[1]
+		parsed[parts[0]] = &value
+	}
+
+	return parsed, nil
+}
+
+// labelsPatch returns the merge patch to set and remove the provided labels of an object.
+func labelsPatch(labels map[string]*string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": labels,
+		},
+	})
+}
+
+// DeletePod deletes the pod with the provided name and namespace.
+func (c *Client) DeletePod(name, namespace string) error {
+	return c.clientset.CoreV1().Pods(namespace).Delete(name, &metav1.DeleteOptions{})
+}
+
+// LabelPod sets and removes the provided labels of the pod with the provided name and namespace.
+func (c *Client) LabelPod(name, namespace string, labels map[string]*string) error {
+	patch, err := labelsPatch(labels)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.CoreV1().Pods(namespace).Patch(name, types.MergePatchType, patch)
+	return err
+}
+
+// LabelNode sets and removes the provided labels of the node with the provided name.
+func (c *Client) LabelNode(name string, labels map[string]*string) error {
+	patch, err := labelsPatch(labels)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.CoreV1().Nodes().Patch(name, types.MergePatchType, patch)
+	return err
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidRecording is thrown if a file is not a recording of kubetop or does not contain any data.
+	ErrInvalidRecording = errors.New("invalid recording")
+)
+
+// Frame is the data of the cluster at one refresh of a recording: all nodes, pods and events at the provided time.
+type Frame struct {
+	Time   time.Time `json:"time"`
+	Nodes  []Node    `json:"nodes"`
+	Pods   []Pod     `json:"pods"`
+	Events []Event   `json:"events"`
+}
+
+// recordingHeader is the first line of a recording. It contains the name of the cluster and the custom columns, so that a recording can be replayed without the configuration which was used for the recording.
+type recordingHeader struct {
+	Clustername string         `json:"clustername"`
+	PodColumns  []CustomColumn `json:"podColumns"`
+	NodeColumns []CustomColumn `json:"nodeColumns"`
+}
+
+// Recorder writes the nodes, pods and events of a cluster to a recording, which can be replayed later.
+// A recording is a gzip compressed file with one JSON document per line: the header and one frame for each refresh.
+// The last time is the time of the last written frame and the error is the last error, which occurred while a frame was written.
+type Recorder struct {
+	client   *Client
+	file     *os.File
+	writer   *gzip.Writer
+	encoder  *json.Encoder
+	last     time.Time
+	err      error
+	reported bool
+	mutex    sync.Mutex
+}
+
+// NewRecorder creates a new recording in the provided directory.
+// The name of the file contains the time when the recording was started (e.g. 'kubetop-20060102-150405.json.gz').
+func NewRecorder(client *Client, dir string) (*Recorder, error) {
+	file, err := os.Create(filepath.Join(dir, "kubetop-"+time.Now().Format("20060102-150405")+".json.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	writer := gzip.NewWriter(file)
+	r := &Recorder{
+		client:  client,
+		file:    file,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+
+	err = r.write(recordingHeader{
+		Clustername: client.GetClustername(),
+		PodColumns:  client.PodCustomColumns(),
+		NodeColumns: client.NodeCustomColumns(),
+	})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Name returns the name of the file of the recording.
+func (r *Recorder) Name() string {
+	return r.file.Name()
+}
+
+// Record writes the nodes, pods and events, which were already fetched by the client for the current view and the cluster summary, as new frame to the recording.
+// We do not fetch the data on our own, so that recording does not double the requests against the Kubernetes API. Data which is older than the provided maximum age is fetched again like for the cluster summary.
+// A frame is only written, when the data was updated since the last frame. Errors while the data is fetched are returned, but they are not returned by Err, because they are already shown by the views.
+func (r *Recorder) Record(maxAge time.Duration) error {
+	frame, err := r.client.summaryFrame(maxAge)
+	if err != nil {
+		return err
+	}
+
+	if !frame.Time.After(r.last) {
+		return nil
+	}
+
+	err = r.write(frame)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Like for the event sink an error is kept until it was returned by Err, also when a later frame was written successfully.
+	if err != nil {
+		if r.err == nil {
+			r.reported = false
+		}
+		r.err = err
+	} else {
+		if r.reported {
+			r.err = nil
+		}
+		r.last = frame.Time
+	}
+
+	return err
+}
+
+// Err returns the last error, which occurred while a frame was written.
+// An error is only returned once until a frame was written successfully again, so that a failing recording is not reported on every refresh.
+func (r *Recorder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.err == nil || r.reported {
+		return nil
+	}
+
+	r.reported = true
+	return r.err
+}
+
+// write writes the provided value as new line to the recording.
+// The compressed data is flushed after each line, so that the recording can be replayed up to the last frame, when kubetop is not closed properly.
+func (r *Recorder) write(v interface{}) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.encoder.Encode(v); err != nil {
+		return err
+	}
+
+	return r.writer.Flush()
+}
+
+// Close finishes the recording and closes the file.
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.writer.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+
+	return r.file.Close()
+}
+
+// readRecording reads the header and all frames of the recording from the provided file.
+// A recording which ends unexpectedly is read up to the last complete frame, because kubetop could have been killed while recording.
+func readRecording(path string) (recordingHeader, []Frame, error) {
+	var header recordingHeader
+	var frames []Frame
+
+	file, err := os.Open(path)
+	if err != nil {
+		return header, nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return header, nil, err
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	if err := decoder.Decode(&header); err != nil {
+		return header, nil, ErrInvalidRecording
+	}
+
+	for {
+		var frame Frame
+		if err := decoder.Decode(&frame); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+
+			return header, nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	if len(frames) == 0 {
+		return header, nil, ErrInvalidRecording
+	}
+
+	return header, frames, nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubetop")
+	if err != nil {
+		t.Fatalf("could not create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	client := &Client{config: &rest.Config{Host: "https://cluster"}}
+	recorder, err := NewRecorder(client, dir)
+	if err != nil {
+		t.Fatalf("could not create recorder: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		update func()
+	}{
+		{"all data", func() {
+			client.recordSummaryNodes([]Node{{Name: "node-1"}})
+			client.recordSummaryPods([]Pod{{Namespace: "default", Name: "pod-1"}})
+			client.recordSummaryEvents([]Event{{UID: "event-1"}})
+		}},
+		{"unchanged data", func() {}},
+		{"updated data", func() { client.recordSummaryPods([]Pod{{Namespace: "default", Name: "pod-2"}}) }},
+	} {
+		tt.update()
+		if err := recorder.Record(time.Hour); err != nil {
+			t.Fatalf("%s: could not record frame: %v", tt.name, err)
+		}
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("could not close recorder: %v", err)
+	}
+
+	header, frames, err := readRecording(recorder.Name())
+	if err != nil {
+		t.Fatalf("could not read recording: %v", err)
+	}
+
+	if header.Clustername != "https://cluster" {
+		t.Errorf("expected clustername https://cluster, got %s", header.Clustername)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+	if frames[0].Pods[0].Name != "pod-1" || frames[1].Pods[0].Name != "pod-2" {
+		t.Errorf("expected frames with pod-1 and pod-2, got %s and %s", frames[0].Pods[0].Name, frames[1].Pods[0].Name)
+	}
+	if len(frames[1].Nodes) != 1 || len(frames[1].Events) != 1 {
+		t.Errorf("expected unchanged nodes and events in the second frame, got %d nodes and %d events", len(frames[1].Nodes), len(frames[1].Events))
+	}
+}
+
+func TestRecorderErr(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubetop")
+	if err != nil {
+		t.Fatalf("could not create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	client := &Client{config: &rest.Config{Host: "https://cluster"}}
+	recorder, err := NewRecorder(client, dir)
+	if err != nil {
+		t.Fatalf("could not create recorder: %v", err)
+	}
+
+	client.recordSummaryNodes(nil)
+	client.recordSummaryPods(nil)
+	client.recordSummaryEvents(nil)
+	recorder.file.Close()
+
+	if err := recorder.Record(time.Hour); err == nil {
+		t.Fatalf("expected error for closed recording")
+	}
+	if err := recorder.Err(); err == nil {
+		t.Errorf("expected error to be reported")
+	}
+	if err := recorder.Err(); err != nil {
+		t.Errorf("expected error to be reported only once, got %v", err)
+	}
+}
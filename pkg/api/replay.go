@@ -0,0 +1,449 @@
+package api
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	// ErrReplay is thrown if an action can not be done in a replay, e.g. deleting a pod.
+	ErrReplay = errors.New("not supported in a replay")
+)
+
+const (
+	// minReplaySpeed and maxReplaySpeed are the limits for the speed of a replay.
+	minReplaySpeed = 0.25
+	maxReplaySpeed = 64
+	// replayWatchInterval is the interval in which the watch of a replay checks for new events.
+	replayWatchInterval = time.Second
+)
+
+// ReplayState is the current state of a replay: the time of the shown frame, the time of the first and the last frame, if the replay is playing and the speed of the replay.
+type ReplayState struct {
+	Time    time.Time
+	Start   time.Time
+	End     time.Time
+	Playing bool
+	Speed   float64
+}
+
+// Replay implements the source for the widgets from a recording.
+// The replay has its own clock, which is advanced by the speed of the replay, while the replay is playing. The widgets get the data of the last frame, which was recorded before the time of the clock.
+// The times in the returned data are moved by the difference between the time of the frame and the current time, so that the ages are shown like they were shown during the recording.
+type Replay struct {
+	clustername string
+	podColumns  []customColumn
+	nodeColumns []customColumn
+	frames      []Frame
+
+	position time.Time
+	resumed  time.Time
+	playing  bool
+	speed    float64
+	mutex    sync.Mutex
+}
+
+// NewReplay returns a new replay for the recording in the provided file.
+// The replay starts paused at the first frame of the recording.
+func NewReplay(path string) (*Replay, error) {
+	header, frames, err := readRecording(path)
+	if err != nil {
+		return nil, err
+	}
+
+	podColumns, err := parseCustomColumns(header.PodColumns, isPodColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeColumns, err := parseCustomColumns(header.NodeColumns, isNodeColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replay{
+		clustername: header.Clustername,
+		podColumns:  podColumns,
+		nodeColumns: nodeColumns,
+		frames:      frames,
+		position:    frames[0].Time,
+		resumed:     time.Now(),
+		playing:     false,
+		speed:       1,
+	}, nil
+}
+
+// clock returns the current time of the replay. When the end of the recording is reached, the replay is paused.
+// The mutex must be locked by the caller.
+func (r *Replay) clock() time.Time {
+	if !r.playing {
+		return r.position
+	}
+
+	now := r.position.Add(time.Duration(float64(time.Since(r.resumed)) * r.speed))
+	if end := r.frames[len(r.frames)-1].Time; now.After(end) {
+		r.position, r.playing = end, false
+		return end
+	}
+
+	return now
+}
+
+// setPosition sets the clock of the replay to the provided time. The time is limited to the time of the first and the last frame.
+// The mutex must be locked by the caller.
+func (r *Replay) setPosition(position time.Time) {
+	if start := r.frames[0].Time; position.Before(start) {
+		position = start
+	}
+
+	if end := r.frames[len(r.frames)-1].Time; position.After(end) {
+		position = end
+	}
+
+	r.position, r.resumed = position, time.Now()
+}
+
+// frame returns a copy of the frame for the current time of the replay, in which all times are moved to the current time.
+func (r *Replay) frame() Frame {
+	r.mutex.Lock()
+	now := r.clock()
+	r.mutex.Unlock()
+
+	index := sort.Search(len(r.frames), func(i int) bool { return r.frames[i].Time.After(now) }) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	frame := r.frames[index]
+	offset := time.Since(frame.Time)
+
+	shifted := Frame{
+		Time:   frame.Time,
+		Nodes:  make([]Node, len(frame.Nodes)),
+		Pods:   make([]Pod, len(frame.Pods)),
+		Events: make([]Event, len(frame.Events)),
+	}
+
+	for i, node := range frame.Nodes {
+		node.CreationDate = node.CreationDate.Add(offset)
+		shifted.Nodes[i] = node
+	}
+
+	for i, pod := range frame.Pods {
+		pod.CreationDate = pod.CreationDate.Add(offset)
+		shifted.Pods[i] = pod
+	}
+
+	for i, event := range frame.Events {
+		shifted.Events[i] = shiftEvent(event, offset)
+	}
+
+	return shifted
+}
+
+// shiftEvent moves all times of the event by the provided offset.
+func shiftEvent(event Event, offset time.Duration) Event {
+	event.Timestamp = event.Timestamp + int64(offset.Seconds())
+	event.FirstTimestamp = event.FirstTimestamp.Add(offset)
+	event.LastTimestamp = event.LastTimestamp.Add(offset)
+	if !event.EventTime.IsZero() {
+		event.EventTime = event.EventTime.Add(offset)
+	}
+
+	return event
+}
+
+// State returns the current state of the replay.
+func (r *Replay) State() ReplayState {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return ReplayState{
+		Time:    r.clock(),
+		Start:   r.frames[0].Time,
+		End:     r.frames[len(r.frames)-1].Time,
+		Playing: r.playing,
+		Speed:   r.speed,
+	}
+}
+
+// TogglePlay starts or pauses the replay. When the replay is started at the end of the recording, it is started again from the beginning.
+func (r *Replay) TogglePlay() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.clock()
+	if !r.playing && !now.Before(r.frames[len(r.frames)-1].Time) {
+		now = r.frames[0].Time
+	}
+
+	r.setPosition(now)
+	r.playing = !r.playing
+}
+
+// SetSpeed multiplies the speed of the replay by the provided factor, e.g. 2 to double the speed.
+func (r *Replay) SetSpeed(factor float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	speed := r.speed * factor
+	if speed < minReplaySpeed || speed > maxReplaySpeed {
+		return
+	}
+
+	r.setPosition(r.clock())
+	r.speed = speed
+}
+
+// Seek moves the replay by the provided offset. A negative offset moves the replay back.
+func (r *Replay) Seek(offset time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.setPosition(r.clock().Add(offset))
+}
+
+// SeekTo moves the replay to the provided time.
+func (r *Replay) SeekTo(position time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.setPosition(position)
+}
+
+// GetClustername returns the name of the cluster, which was recorded.
+func (r *Replay) GetClustername() string {
+	return r.clustername
+}
+
+// GetNamespaces returns the namespaces of all pods and events of the current frame.
+func (r *Replay) GetNamespaces() ([]string, error) {
+	frame := r.frame()
+
+	var namespaces []string
+	for _, pod := range frame.Pods {
+		namespaces = append(namespaces, pod.Namespace)
+	}
+	for _, event := range frame.Events {
+		namespaces = append(namespaces, event.Namespace)
+	}
+
+	return append([]string{"-"}, uniqueStrings(namespaces)...), nil
+}
+
+// GetNodes returns the names of all nodes of the current frame.
+func (r *Replay) GetNodes() ([]string, error) {
+	var nodes []string
+	for _, node := range r.frame().Nodes {
+		nodes = append(nodes, node.Name)
+	}
+
+	return append([]string{"-"}, nodes...), nil
+}
+
+// GetWorkloadSelector returns an error, because workloads are not recorded.
+func (r *Replay) GetWorkloadSelector(kind, namespace, name string) (string, error) {
+	return "", ErrUnknownWorkload
+}
+
+// GetNodesMetrics returns all nodes of the current frame.
+func (r *Replay) GetNodesMetrics(sortorder Sort) ([]Node, error) {
+	nodes := r.frame().Nodes
+	sortNodes(nodes, sortorder, r.nodeColumns)
+
+	return nodes, nil
+}
+
+// GetPodsMetrics returns all pods of the current frame, which match the provided filter.
+func (r *Replay) GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error) {
+	if _, err := labels.Parse(filter.Selector); err != nil {
+		return nil, err
+	}
+
+	var pods []Pod
+	for _, pod := range r.frame().Pods {
+		if filter.MatchesPod(pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	sortPods(pods, sortorder, r.podColumns)
+
+	return pods, nil
+}
+
+// GetPod returns a pod of the current frame with its events.
+// The containers and logs of a pod are not recorded, so we only return the values which are shown in the pods view.
+func (r *Replay) GetPod(name, namespace string, selectedContainer int) (*Pod, error) {
+	frame := r.frame()
+
+	for _, pod := range frame.Pods {
+		if pod.Name != name || pod.Namespace != namespace {
+			continue
+		}
+
+		for _, event := range frame.Events {
+			if event.InvolvedObject.Kind == "Pod" && event.InvolvedObject.Name == name && event.InvolvedObject.Namespace == namespace {
+				pod.Events = append(pod.Events, event)
+			}
+		}
+
+		pod.LogLines = []string{"Logs are not available in a replay."}
+		return &pod, nil
+	}
+
+	return nil, errors.New("pod " + namespace + "/" + name + " not found in the recording")
+}
+
+// GetEvents returns all events of the current frame, which match the provided filter.
+func (r *Replay) GetEvents(filter Filter, sortorder Sort) ([]Event, error) {
+	var events []Event
+	for _, event := range r.frame().Events {
+		if (filter.Namespace == "" || filter.Namespace == event.Namespace) && matchEvent(filter, event) {
+			events = append(events, event)
+		}
+	}
+
+	sortEvents(events, sortorder)
+
+	return events, nil
+}
+
+// ListEvents returns all events of the current frame, which match the provided filter.
+// A recording has no resource version, so an empty resource version is returned.
+func (r *Replay) ListEvents(filter Filter, sortorder Sort) ([]Event, string, error) {
+	events, err := r.GetEvents(filter, sortorder)
+	return events, "", err
+}
+
+// GetEvent returns a single event of the current frame.
+func (r *Replay) GetEvent(name, namespace string) Event {
+	for _, event := range r.frame().Events {
+		if event.Name == name && event.Namespace == namespace {
+			return event
+		}
+	}
+
+	return Event{}
+}
+
+// GetEventReasons returns all reasons of the events in the provided namespace of the current frame.
+func (r *Replay) GetEventReasons(namespace string) ([]string, error) {
+	var reasons []string
+	for _, event := range r.frame().Events {
+		if event.Reason != "" && (namespace == "" || namespace == event.Namespace) {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+
+	return append([]string{"-"}, uniqueStrings(reasons)...), nil
+}
+
+// GroupEvents groups the provided events.
+// Because the workloads are not recorded, the owner of a pod is the first controller of the pod (e.g. the replica set) and all other objects are their own owner.
+func (r *Replay) GroupEvents(events []Event) []EventGroup {
+	owners := make(map[string]ObjectReference)
+	for _, pod := range r.frame().Pods {
+		if len(pod.ControlledBy) > 0 {
+			if parts := strings.SplitN(pod.ControlledBy[0], "/", 2); len(parts) == 2 {
+				owners[pod.Namespace+"/"+pod.Name] = ObjectReference{Kind: parts[0], Namespace: pod.Namespace, Name: parts[1]}
+			}
+		}
+	}
+
+	return groupEvents(events, func(ref ObjectReference) ObjectReference {
+		if owner, ok := owners[ref.Namespace+"/"+ref.Name]; ok && ref.Kind == "Pod" {
+			return owner
+		}
+
+		return ref
+	})
+}
+
+// WatchEvents sends all events, which are new or were updated in the frames shown after the call, to the returned channel.
+// The resource version is ignored, because the events are not watched from the Kubernetes API.
+func (r *Replay) WatchEvents(filter Filter, resourceVersion string, stop <-chan struct{}) <-chan Event {
+	events := make(chan Event, 100)
+
+	seen := make(map[string]bool)
+	for _, event := range r.frame().Events {
+		seen[event.UID+"/"+event.ResourceVersion] = true
+	}
+
+	go func() {
+		defer close(events)
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(replayWatchInterval):
+			}
+
+			for _, event := range r.frame().Events {
+				key := event.UID + "/" + event.ResourceVersion
+				if seen[key] || (filter.Namespace != "" && filter.Namespace != event.Namespace) || !matchEvent(filter, event) {
+					continue
+				}
+
+				seen[key] = true
+				select {
+				case events <- event:
+				case <-stop:
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// GetSummary returns the summary of the cluster for the current frame.
+func (r *Replay) GetSummary(maxAge time.Duration) (*Summary, error) {
+	frame := r.frame()
+	return newSummary(frame.Nodes, frame.Pods, frame.Events), nil
+}
+
+// PodCustomColumns returns the custom columns for pods, which were used for the recording.
+func (r *Replay) PodCustomColumns() []CustomColumn {
+	return unparseCustomColumns(r.podColumns)
+}
+
+// NodeCustomColumns returns the custom columns for nodes, which were used for the recording.
+func (r *Replay) NodeCustomColumns() []CustomColumn {
+	return unparseCustomColumns(r.nodeColumns)
+}
+
+// DeletePod, LabelPod and LabelNode return an error, because the cluster can not be changed in a replay.
+func (r *Replay) DeletePod(name, namespace string) error {
+	return ErrReplay
+}
+
+func (r *Replay) LabelPod(name, namespace string, labels map[string]*string) error {
+	return ErrReplay
+}
+
+func (r *Replay) LabelNode(name string, labels map[string]*string) error {
+	return ErrReplay
+}
+
+// uniqueStrings returns the sorted unique values of the provided slice.
+func uniqueStrings(values []string) []string {
+	found := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if !found[value] {
+			found[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	sort.Strings(unique)
+	return unique
+}
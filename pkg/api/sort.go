@@ -0,0 +1,337 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortKey is a single key for sorting the data which is returned by the Kubernetes API.
+// The column is the name of the column in the corresponding view (e.g. 'cpu' or 'namespace').
+type SortKey struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc"`
+}
+
+// String returns the sort key in the format '<column> (A)' or '<column> (D)'.
+func (k SortKey) String() string {
+	if k.Desc {
+		return k.Column + " (D)"
+	}
+
+	return k.Column + " (A)"
+}
+
+// Sort is our custom type which represents the sort order for the data which is returned by the Kubernetes API.
+// The data is sorted by the primary key. If two items are equal for the primary key, they are sorted by the secondary key.
+// The secondary key is optional and not used when the column is empty.
+type Sort struct {
+	Primary   SortKey `json:"primary"`
+	Secondary SortKey `json:"secondary"`
+}
+
+// NewSort returns a new sortorder for the provided column.
+func NewSort(column string, desc bool) Sort {
+	return Sort{Primary: SortKey{Column: column, Desc: desc}}
+}
+
+// String returns the sortorder as it is shown in the statusbar.
+func (s Sort) String() string {
+	if s.Secondary.Column == "" {
+		return s.Primary.String()
+	}
+
+	return s.Primary.String() + ", " + s.Secondary.String()
+}
+
+// SortBy returns the sortorder for the provided column.
+// If the data is already sorted by the column, the direction is toggled. The secondary key is kept, as long as it is not the new primary key.
+func (s Sort) SortBy(column string) Sort {
+	if s.Primary.Column == column {
+		return s.Reverse()
+	}
+
+	s.Primary = SortKey{Column: column}
+	if s.Secondary.Column == column {
+		s.Secondary = SortKey{}
+	}
+
+	return s
+}
+
+// Reverse returns the sortorder with the toggled direction of the primary key.
+func (s Sort) Reverse() Sort {
+	s.Primary.Desc = !s.Primary.Desc
+	return s
+}
+
+// ThenBy returns the sortorder with the provided column as secondary key.
+// If the column is already the secondary key, the direction is toggled. If the column is empty or the primary key, the secondary key is removed.
+func (s Sort) ThenBy(column string) Sort {
+	if column == "" || column == s.Primary.Column {
+		s.Secondary = SortKey{}
+	} else if s.Secondary.Column == column {
+		s.Secondary.Desc = !s.Secondary.Desc
+	} else {
+		s.Secondary = SortKey{Column: column}
+	}
+
+	return s
+}
+
+// comparator compares two items of a slice and returns a negative number if the first item is smaller, zero if they are equal and a positive number if the first item is larger.
+type comparator func(i, j int) int
+
+// nodeComparators, podComparators and eventComparators contain the comparators for all sortable columns of the nodes, pods and events view.
+// The comparators are created for a slice, because the custom columns need the values of the slice.
+var nodeComparators = map[string]func(nodes []Node) comparator{
+	"name": func(n []Node) comparator { return func(i, j int) int { return compareString(n[i].Name, n[j].Name) } },
+	"pods": func(n []Node) comparator {
+		return func(i, j int) int { return compareInt64(int64(n[i].PodsCount), int64(n[j].PodsCount)) }
+	},
+	"cpu": func(n []Node) comparator {
+		return func(i, j int) int { return compareInt64(n[i].CPUUsed, n[j].CPUUsed) }
+	},
+	"memory": func(n []Node) comparator {
+		return func(i, j int) int { return compareInt64(n[i].MemoryUsed, n[j].MemoryUsed) }
+	},
+	"memoryMax": func(n []Node) comparator {
+		return func(i, j int) int { return compareInt64(n[i].MemoryTotal, n[j].MemoryTotal) }
+	},
+	"externalIP": func(n []Node) comparator {
+		return func(i, j int) int { return compareString(n[i].ExternalIP, n[j].ExternalIP) }
+	},
+	"internalIP": func(n []Node) comparator {
+		return func(i, j int) int { return compareString(n[i].InternalIP, n[j].InternalIP) }
+	},
+	"status": func(n []Node) comparator {
+		return func(i, j int) int { return compareString(n[i].Status, n[j].Status) }
+	},
+	"age": func(n []Node) comparator {
+		return func(i, j int) int { return compareTime(n[j].CreationDate, n[i].CreationDate) }
+	},
+}
+
+var podComparators = map[string]func(pods []Pod) comparator{
+	"namespace": func(p []Pod) comparator {
+		return func(i, j int) int { return compareString(p[i].Namespace, p[j].Namespace) }
+	},
+	"name": func(p []Pod) comparator { return func(i, j int) int { return compareString(p[i].Name, p[j].Name) } },
+	"ready": func(p []Pod) comparator {
+		return func(i, j int) int { return compareInt64(p[i].ContainersReady, p[j].ContainersReady) }
+	},
+	"status": func(p []Pod) comparator {
+		return func(i, j int) int {
+			if c := compareInt64(int64(p[i].StatusGeneral), int64(p[j].StatusGeneral)); c != 0 {
+				return c
+			}
+			return compareString(p[i].Status, p[j].Status)
+		}
+	},
+	"restarts": func(p []Pod) comparator {
+		return func(i, j int) int { return compareInt64(p[i].Restarts, p[j].Restarts) }
+	},
+	"cpu":    func(p []Pod) comparator { return func(i, j int) int { return compareInt64(p[i].CPU, p[j].CPU) } },
+	"cpuMax": func(p []Pod) comparator { return func(i, j int) int { return compareInt64(p[i].CPUMax, p[j].CPUMax) } },
+	"memory": func(p []Pod) comparator { return func(i, j int) int { return compareInt64(p[i].Memory, p[j].Memory) } },
+	"memoryMax": func(p []Pod) comparator {
+		return func(i, j int) int { return compareInt64(p[i].MemoryMax, p[j].MemoryMax) }
+	},
+	"ip": func(p []Pod) comparator { return func(i, j int) int { return compareIP(p[i].IP, p[j].IP) } },
+	"age": func(p []Pod) comparator {
+		return func(i, j int) int { return compareTime(p[j].CreationDate, p[i].CreationDate) }
+	},
+	"node": func(p []Pod) comparator {
+		return func(i, j int) int { return compareString(p[i].NodeName, p[j].NodeName) }
+	},
+	"qos": func(p []Pod) comparator {
+		return func(i, j int) int { return compareString(p[i].QOSClass, p[j].QOSClass) }
+	},
+	"owner": func(p []Pod) comparator {
+		return func(i, j int) int {
+			return compareString(strings.Join(p[i].ControlledBy, ","), strings.Join(p[j].ControlledBy, ","))
+		}
+	},
+}
+
+var eventComparators = map[string]func(events []Event) comparator{
+	"age": func(e []Event) comparator {
+		return func(i, j int) int { return compareInt64(e[j].Timestamp, e[i].Timestamp) }
+	},
+	"count": func(e []Event) comparator {
+		return func(i, j int) int { return compareInt64(int64(e[i].Count), int64(e[j].Count)) }
+	},
+	"type": func(e []Event) comparator { return func(i, j int) int { return compareString(e[i].Type, e[j].Type) } },
+	"namespace": func(e []Event) comparator {
+		return func(i, j int) int { return compareString(e[i].Namespace, e[j].Namespace) }
+	},
+	"name": func(e []Event) comparator { return func(i, j int) int { return compareString(e[i].Name, e[j].Name) } },
+	"message": func(e []Event) comparator {
+		return func(i, j int) int { return compareString(e[i].Message, e[j].Message) }
+	},
+	"kind": func(e []Event) comparator { return func(i, j int) int { return compareString(e[i].Kind, e[j].Kind) } },
+	"reason": func(e []Event) comparator {
+		return func(i, j int) int { return compareString(e[i].Reason, e[j].Reason) }
+	},
+	"source": func(e []Event) comparator {
+		return func(i, j int) int { return compareString(e[i].Source, e[j].Source) }
+	},
+	"node": func(e []Event) comparator { return func(i, j int) int { return compareString(e[i].Node, e[j].Node) } },
+	"object": func(e []Event) comparator {
+		return func(i, j int) int { return compareString(e[i].InvolvedObject.Name, e[j].InvolvedObject.Name) }
+	},
+}
+
+// sortNodes, sortPods and sortEvents sort the slices by the provided sortorder.
+// Custom columns are sorted by their values, when there is no built-in column with the same name.
+func sortNodes(nodes []Node, sortorder Sort, columns []customColumn) {
+	sortSlice(nodes, sortorder, func(column string) comparator {
+		if newComparator, ok := nodeComparators[column]; ok {
+			return newComparator(nodes)
+		}
+
+		return customComparator(columns, column, func(i int) []string { return nodes[i].Custom })
+	})
+}
+
+func sortPods(pods []Pod, sortorder Sort, columns []customColumn) {
+	sortSlice(pods, sortorder, func(column string) comparator {
+		if newComparator, ok := podComparators[column]; ok {
+			return newComparator(pods)
+		}
+
+		return customComparator(columns, column, func(i int) []string { return pods[i].Custom })
+	})
+}
+
+func sortEvents(events []Event, sortorder Sort) {
+	sortSlice(events, sortorder, func(column string) comparator {
+		if newComparator, ok := eventComparators[column]; ok {
+			return newComparator(events)
+		}
+
+		return nil
+	})
+}
+
+// sortSlice sorts the slice by the primary and secondary key of the sortorder.
+// Unknown columns are ignored, so that the order of the slice is not changed for them.
+func sortSlice(slice interface{}, sortorder Sort, comparatorFor func(column string) comparator) {
+	var comparators []comparator
+	var desc []bool
+
+	for _, key := range []SortKey{sortorder.Primary, sortorder.Secondary} {
+		if key.Column == "" {
+			continue
+		}
+
+		if c := comparatorFor(key.Column); c != nil {
+			comparators = append(comparators, c)
+			desc = append(desc, key.Desc)
+		}
+	}
+
+	if len(comparators) == 0 {
+		return
+	}
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		for index, c := range comparators {
+			result := c(i, j)
+			if desc[index] {
+				result = -result
+			}
+
+			if result != 0 {
+				return result < 0
+			}
+		}
+
+		return false
+	})
+}
+
+// customComparator returns the comparator for a custom column.
+// If the values of both items are numbers, we compare them as numbers, otherwise the values are compared as strings.
+func customComparator(columns []customColumn, column string, values func(i int) []string) comparator {
+	for index, c := range columns {
+		if c.Name != column {
+			continue
+		}
+
+		return func(i, j int) int {
+			a, b := values(i), values(j)
+			if index >= len(a) || index >= len(b) {
+				return 0
+			}
+
+			numberA, errA := strconv.ParseFloat(a[index], 64)
+			numberB, errB := strconv.ParseFloat(b[index], 64)
+			if errA == nil && errB == nil {
+				return compareFloat64(numberA, numberB)
+			}
+
+			return compareString(a[index], b[index])
+		}
+	}
+
+	return nil
+}
+
+func compareString(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+func compareInt64(a, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+
+	return 0
+}
+
+func compareTime(a, b time.Time) int {
+	if a.Before(b) {
+		return -1
+	} else if a.After(b) {
+		return 1
+	}
+
+	return 0
+}
+
+// compareIP compares two ip addresses by their octets, so that '10.0.0.2' is sorted before '10.0.0.10'.
+// If one of the values is not an IPv4 address, the values are compared as strings.
+func compareIP(a, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	if len(partsA) != 4 || len(partsB) != 4 {
+		return compareString(a, b)
+	}
+
+	for index := range partsA {
+		octetA, errA := strconv.Atoi(partsA[index])
+		octetB, errB := strconv.Atoi(partsB[index])
+		if errA != nil || errB != nil {
+			return compareString(a, b)
+		}
+
+		if c := compareInt64(int64(octetA), int64(octetB)); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortPods(t *testing.T) {
+	pods := []Pod{
+		{Namespace: "b", Name: "a", CPU: 10, IP: "10.0.0.10"},
+		{Namespace: "a", Name: "b", CPU: 30, IP: "10.0.0.2"},
+		{Namespace: "a", Name: "c", CPU: 10, IP: "10.0.0.1"},
+		{Namespace: "b", Name: "d", CPU: 20, IP: "10.0.0.3"},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		sortorder Sort
+		want      []string
+	}{
+		{"ascending", NewSort("cpu", false), []string{"a", "c", "d", "b"}},
+		{"descending", NewSort("cpu", true), []string{"b", "d", "a", "c"}},
+		{"secondary key", Sort{SortKey{"namespace", false}, SortKey{"cpu", true}}, []string{"b", "c", "d", "a"}},
+		{"secondary key ascending", Sort{SortKey{"namespace", true}, SortKey{"name", false}}, []string{"a", "d", "b", "c"}},
+		{"ip addresses", NewSort("ip", false), []string{"c", "b", "d", "a"}},
+		{"unknown column", NewSort("unknown", false), []string{"a", "b", "c", "d"}},
+		{"unknown secondary column", Sort{SortKey{"cpu", false}, SortKey{"unknown", false}}, []string{"a", "c", "d", "b"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]Pod(nil), pods...)
+			sortPods(sorted, tt.sortorder, nil)
+
+			var names []string
+			for _, pod := range sorted {
+				names = append(names, pod.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+}
+
+func TestSortCustomColumns(t *testing.T) {
+	columns := []customColumn{{CustomColumn: CustomColumn{Name: "replicas"}}, {CustomColumn: CustomColumn{Name: "team"}}}
+	nodes := []Node{
+		{Name: "a", Custom: []string{"10", "payments"}},
+		{Name: "b", Custom: []string{"9", "checkout"}},
+		{Name: "c", Custom: []string{"100"}},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		sortorder Sort
+		want      []string
+	}{
+		{"numbers", NewSort("replicas", false), []string{"b", "a", "c"}},
+		{"strings", NewSort("team", false), []string{"b", "a", "c"}},
+		{"built-in column", NewSort("name", true), []string{"c", "b", "a"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]Node(nil), nodes...)
+			sortNodes(sorted, tt.sortorder, columns)
+
+			var names []string
+			for _, node := range sorted {
+				names = append(names, node.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+}
+
+func TestComparators(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"string less", compareString("a", "b"), -1},
+		{"string equal", compareString("a", "a"), 0},
+		{"int64 greater", compareInt64(2, 1), 1},
+		{"int64 equal", compareInt64(1, 1), 0},
+		{"float64 less", compareFloat64(1.5, 2), -1},
+		{"time less", compareTime(now, now.Add(time.Second)), -1},
+		{"time greater", compareTime(now.Add(time.Second), now), 1},
+		{"ip octets", compareIP("10.0.0.2", "10.0.0.10"), -1},
+		{"ip equal", compareIP("10.0.0.2", "10.0.0.2"), 0},
+		{"ip without address", compareIP("", "10.0.0.1"), -1},
+		{"ip invalid octet", compareIP("10.0.0.a", "10.0.0.1"), 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, tt.got)
+			}
+		})
+	}
+}
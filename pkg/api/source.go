@@ -0,0 +1,29 @@
+package api
+
+import (
+	"time"
+)
+
+// Source is the interface for the data, which is shown by kubetop.
+// It is implemented by the client for a Kubernetes cluster and by the replay of a recording, so that the widgets can show the live data of a cluster and the data of a recording.
+type Source interface {
+	GetClustername() string
+	GetNamespaces() ([]string, error)
+	GetNodes() ([]string, error)
+	GetWorkloadSelector(kind, namespace, name string) (string, error)
+	GetNodesMetrics(sortorder Sort) ([]Node, error)
+	GetPodsMetrics(filter Filter, sortorder Sort) ([]Pod, error)
+	GetPod(name, namespace string, selectedContainer int) (*Pod, error)
+	GetEvents(filter Filter, sortorder Sort) ([]Event, error)
+	ListEvents(filter Filter, sortorder Sort) ([]Event, string, error)
+	GetEvent(name, namespace string) Event
+	GetEventReasons(namespace string) ([]string, error)
+	GroupEvents(events []Event) []EventGroup
+	WatchEvents(filter Filter, resourceVersion string, stop <-chan struct{}) <-chan Event
+	GetSummary(maxAge time.Duration) (*Summary, error)
+	PodCustomColumns() []CustomColumn
+	NodeCustomColumns() []CustomColumn
+	DeletePod(name, namespace string) error
+	LabelPod(name, namespace string, labels map[string]*string) error
+	LabelNode(name string, labels map[string]*string) error
+}
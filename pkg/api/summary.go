@@ -0,0 +1,171 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// Summary represents the health of the whole cluster.
+// It contains the number of ready and not ready nodes, the number of pods by their general status, the used and allocatable resources of all nodes and the number of warning events in the last five minutes.
+type Summary struct {
+	NodesReady        int
+	NodesNotReady     int
+	PodsRunning       int
+	PodsWaiting       int
+	PodsTerminated    int
+	CPUUsed           int64
+	CPUAllocatable    int64
+	MemoryUsed        int64
+	MemoryAllocatable int64
+	WarningEvents     int
+}
+
+// summaryCache contains the last unfiltered nodes, pods and events, which were fetched by the client.
+// The cache is used to build the cluster summary from the data, which is already fetched by the current view.
+type summaryCache struct {
+	nodes        []Node
+	nodesUpdated time.Time
+
+	pods        []Pod
+	podsUpdated time.Time
+
+	events        []Event
+	eventsUpdated time.Time
+
+	mutex sync.Mutex
+}
+
+// summaryWarningEventsWindow is the time window in which warning events are counted for the cluster summary.
+const summaryWarningEventsWindow = 5 * time.Minute
+
+// isUnfiltered returns true if the filter does not exclude any pod or event.
+// Only unfiltered results can be used for the cluster summary.
+func isUnfiltered(filter Filter) bool {
+	return filter.Namespace == "" && filter.Node == "" && filter.Status == 10 && filter.EventType == "" && filter.Reason == "" && filter.Selector == ""
+}
+
+// recordSummaryNodes, recordSummaryPods and recordSummaryEvents save the fetched data in the cache for the cluster summary.
+// We copy the nodes and pods, because the slices are sorted after they were recorded.
+func (c *Client) recordSummaryNodes(nodes []Node) {
+	c.summary.mutex.Lock()
+	defer c.summary.mutex.Unlock()
+
+	c.summary.nodes = append([]Node(nil), nodes...)
+	c.summary.nodesUpdated = time.Now()
+}
+
+func (c *Client) recordSummaryPods(pods []Pod) {
+	c.summary.mutex.Lock()
+	defer c.summary.mutex.Unlock()
+
+	c.summary.pods = append([]Pod(nil), pods...)
+	c.summary.podsUpdated = time.Now()
+}
+
+func (c *Client) recordSummaryEvents(events []Event) {
+	c.summary.mutex.Lock()
+	defer c.summary.mutex.Unlock()
+
+	c.summary.events = events
+	c.summary.eventsUpdated = time.Now()
+}
+
+// GetSummary returns the summary for the cluster.
+// The summary is built from the nodes, pods and events which were already fetched by the current view.
+// If the data for one of these resources is older than the provided maximum age, we fetch the data again. This is the case for all resources which are not shown in the current view.
+// The summary is updated by the refresh of the views, so that the data is not fetched concurrently to the refresh of the current view.
+func (c *Client) GetSummary(maxAge time.Duration) (*Summary, error) {
+	if err := c.refreshSummary(maxAge); err != nil {
+		return nil, err
+	}
+
+	c.summary.mutex.Lock()
+	defer c.summary.mutex.Unlock()
+
+	return newSummary(c.summary.nodes, c.summary.pods, c.summary.events), nil
+}
+
+// summaryFrame returns the cached nodes, pods and events as frame for a recording. The time of the frame is the time of the last update of the cache.
+// Like for the summary the data, which is older than the provided maximum age, is fetched again.
+func (c *Client) summaryFrame(maxAge time.Duration) (Frame, error) {
+	if err := c.refreshSummary(maxAge); err != nil {
+		return Frame{}, err
+	}
+
+	c.summary.mutex.Lock()
+	defer c.summary.mutex.Unlock()
+
+	frame := Frame{Time: c.summary.nodesUpdated, Nodes: c.summary.nodes, Pods: c.summary.pods, Events: c.summary.events}
+	for _, updated := range []time.Time{c.summary.podsUpdated, c.summary.eventsUpdated} {
+		if updated.After(frame.Time) {
+			frame.Time = updated
+		}
+	}
+
+	return frame, nil
+}
+
+// refreshSummary fetches all nodes, pods and events, which are older than the provided maximum age.
+// The fetched data is saved in the cache by the record functions. The mutex of the cache is not held while the data is fetched, because the record functions lock it.
+func (c *Client) refreshSummary(maxAge time.Duration) error {
+	c.summary.mutex.Lock()
+	nodesUpdated, podsUpdated, eventsUpdated := c.summary.nodesUpdated, c.summary.podsUpdated, c.summary.eventsUpdated
+	c.summary.mutex.Unlock()
+
+	if time.Now().Sub(nodesUpdated) > maxAge {
+		if _, err := c.GetNodesMetrics(NewSort("name", false)); err != nil {
+			return err
+		}
+	}
+
+	if time.Now().Sub(podsUpdated) > maxAge {
+		if _, err := c.GetPodsMetrics(Filter{Namespace: "", Node: "", Status: 10}, NewSort("namespace", false)); err != nil {
+			return err
+		}
+	}
+
+	if time.Now().Sub(eventsUpdated) > maxAge {
+		if _, err := c.GetEvents(Filter{Namespace: "", Node: "", Status: 10}, NewSort("age", false)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newSummary returns the summary for the provided nodes, pods and events.
+func newSummary(nodes []Node, pods []Pod, events []Event) *Summary {
+	var summary Summary
+
+	for _, node := range nodes {
+		if node.Status == "Ready" {
+			summary.NodesReady++
+		} else {
+			summary.NodesNotReady++
+		}
+
+		summary.CPUUsed = summary.CPUUsed + node.CPUUsed
+		summary.CPUAllocatable = summary.CPUAllocatable + node.CPUTotal
+		summary.MemoryUsed = summary.MemoryUsed + node.MemoryUsed
+		summary.MemoryAllocatable = summary.MemoryAllocatable + node.MemoryTotal
+	}
+
+	for _, pod := range pods {
+		switch pod.StatusGeneral {
+		case 2:
+			summary.PodsRunning++
+		case 1:
+			summary.PodsWaiting++
+		default:
+			summary.PodsTerminated++
+		}
+	}
+
+	for _, event := range events {
+		if event.Type == "Warning" && time.Now().Sub(event.LastTimestamp) <= summaryWarningEventsWindow {
+			summary.WarningEvents++
+		}
+	}
+
+	return &summary
+}
@@ -2,18 +2,23 @@ package api
 
 import (
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Node represents a node in the Kubernetes cluster with all needed fields.
 type Node struct {
-	Name        string
-	PodsCount   int
-	MemoryTotal int64
-	MemoryUsed  int64
-	CPUTotal    int64
-	CPUUsed     int64
-	ExternalIP  string
-	InternalIP  string
+	Name         string
+	PodsCount    int
+	MemoryTotal  int64
+	MemoryUsed   int64
+	CPUTotal     int64
+	CPUUsed      int64
+	ExternalIP   string
+	InternalIP   string
+	Status       string
+	CreationDate time.Time
+	Custom       []string
 }
 
 // Pod represents a pod in the Kubernetes cluster with all needed fields.
@@ -32,6 +37,8 @@ type Pod struct {
 	Status                  string
 	StatusGeneral           int
 	Restarts                int64
+	LastTerminationReason   string
+	QOSClass                string
 	Labels                  map[string]string
 	Annotations             map[string]string
 	ControlledBy            []string
@@ -40,6 +47,7 @@ type Pod struct {
 	Containers              []Container
 	LogLines                []string
 	Events                  []Event
+	Custom                  []string
 }
 
 // Container represents a container in a pod of the Kubernetes cluster with all needed fields.
@@ -53,56 +61,66 @@ type Container struct {
 	CPUMin    int64
 	Status    string
 	Restarts  int32
+
+	LastTerminationState *ContainerTermination
+}
+
+// ContainerTermination represents the state of a container, when it was terminated the last time.
+// The signal is zero, if the container was not terminated by a signal.
+type ContainerTermination struct {
+	Reason     string
+	Message    string
+	ExitCode   int32
+	Signal     int32
+	StartedAt  time.Time
+	FinishedAt time.Time
 }
 
 // Event represents a event in a pod of the Kubernetes cluster with all needed fields.
 type Event struct {
-	UID            string
-	Message        string
-	Timestamp      int64
-	Count          int32
-	Name           string
-	Namespace      string
+	UID                 string          `json:"uid"`
+	ResourceVersion     string          `json:"resourceVersion"`
+	Message             string          `json:"message"`
+	Timestamp           int64           `json:"timestamp"`
+	Count               int32           `json:"count"`
+	Name                string          `json:"name"`
+	Namespace           string          `json:"namespace"`
+	Kind                string          `json:"kind"`
+	Type                string          `json:"type"`
+	Reason              string          `json:"reason"`
+	Source              string          `json:"source"`
+	Node                string          `json:"node"`
+	FirstTimestamp      time.Time       `json:"firstTimestamp"`
+	LastTimestamp       time.Time       `json:"lastTimestamp"`
+	EventTime           time.Time       `json:"eventTime"`
+	ReportingController string          `json:"reportingController"`
+	ReportingInstance   string          `json:"reportingInstance"`
+	InvolvedObject      ObjectReference `json:"involvedObject"`
+}
+
+// EventGroup represents a group of events with the same kind of the involved object, reason and owner workload.
+// The count is the sum of the counts of all events in the group and the message is the message of the last event.
+type EventGroup struct {
+	Key            string
 	Kind           string
-	Type           string
 	Reason         string
-	Source         string
-	Node           string
+	Owner          ObjectReference
+	Namespace      string
+	Type           string
+	Count          int32
+	Message        string
 	FirstTimestamp time.Time
 	LastTimestamp  time.Time
+	Events         []Event
 }
 
-// Sort is our custom type which represents the sort order for the data which is returned by the Kubernetes API.
-type Sort string
-
-const (
-	// SortCPUASC sorts the results of an API request by cpu usage (ASC).
-	SortCPUASC Sort = "CPU (A)"
-	// SortCPUDESC sorts the results of an API request by cpu usage (DESC).
-	SortCPUDESC Sort = "CPU (D)"
-	// SortMemoryASC sorts the results of an API request by memory usage (ASC).
-	SortMemoryASC Sort = "Memory (A)"
-	// SortMemoryDESC sorts the results of an API request by memory usage (DESC).
-	SortMemoryDESC Sort = "Memory (D)"
-	// SortName sorts the results of an API request by the name.
-	SortName Sort = "Name"
-	// SortNamespace sorts the results of an API request by the namespace.
-	SortNamespace Sort = "Namespace"
-	// SortPodsASC sorts the results of an API request by the number of pods (ASC).
-	SortPodsASC Sort = "Pods (A)"
-	// SortPodsDESC sorts the results of an API request by the number of pods (DESC).
-	SortPodsDESC Sort = "Pods (D)"
-	// SortRestartsASC sorts the results of an API request by the number of restarts (asc).
-	SortRestartsASC Sort = "Restarts (A)"
-	// SortRestartsDESC sorts the results of an API request by the number of restarts (desc).
-	SortRestartsDESC Sort = "Restarts (D)"
-	// SortStatus sorts the results of an API request by the status.
-	SortStatus Sort = "Status"
-	// SortTimeASC sorts the results of an API request by the timestamp (asc).
-	SortTimeASC = "Timestamp (A)"
-	// SortTimeDESC sorts the results of an API request by the timestamp (desc).
-	SortTimeDESC = "Timestamp (D)"
-)
+// ObjectReference represents the object in the Kubernetes cluster for which an event was fired.
+type ObjectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+}
 
 // Filter is our custom type which applies a filter for the data which is returned by the Kubernetes API.
 type Filter struct {
@@ -110,4 +128,17 @@ type Filter struct {
 	Node      string
 	Status    int
 	EventType string
+	Reason    string
+	Selector  string
+}
+
+// MatchesPod returns true if the pod is not excluded by the namespace, node, status and selector of the filter.
+// If the selector is invalid, no pod matches the filter.
+func (f Filter) MatchesPod(pod Pod) bool {
+	selector, err := labels.Parse(f.Selector)
+	if err != nil {
+		return false
+	}
+
+	return (f.Namespace == "" || f.Namespace == pod.Namespace) && (f.Node == "" || f.Node == pod.NodeName) && (f.Status == 10 || f.Status == pod.StatusGeneral) && selector.Matches(labels.Set(pod.Labels))
 }
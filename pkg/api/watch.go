@@ -0,0 +1,117 @@
+package api
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// watchRetryInterval is the time we wait before we try to start a new watch, when the last one failed.
+	watchRetryInterval = 5 * time.Second
+)
+
+// getEventsResourceVersion returns the current resource version for the events in the given namespace.
+// We only request one event, because we are only interested in the resource version of the list.
+func (c *Client) getEventsResourceVersion(namespace string) (string, error) {
+	_, resourceVersion, err := c.listEvents(namespace, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return "", err
+	}
+
+	return resourceVersion, nil
+}
+
+// WatchEvents watches for new and updated events and sends them to the returned channel.
+// The watch starts at the provided resource version, which should be the resource version returned by ListEvents, so that no event between the list and the watch is missed.
+// If the resource version is empty, only events which are fired after the call are returned.
+// When the watch is closed by the Kubernetes API server, we resume it from the resource version of the last received event.
+// Only if this resource version is too old (410 Gone), we start again with the current resource version, so that we do not get all existing events a second time.
+// The watch runs until the stop channel is closed, then the returned channel is closed.
+func (c *Client) WatchEvents(filter Filter, resourceVersion string, stop <-chan struct{}) <-chan Event {
+	events := make(chan Event, 100)
+
+	go func() {
+		defer close(events)
+
+		for {
+			var err error
+			if resourceVersion == "" {
+				resourceVersion, err = c.getEventsResourceVersion(filter.Namespace)
+			}
+
+			var watcher watch.Interface
+			if err == nil {
+				watcher, err = c.watchEventsAPI(filter.Namespace, resourceVersion)
+			}
+
+			if err != nil {
+				select {
+				case <-stop:
+					return
+				case <-time.After(watchRetryInterval):
+					continue
+				}
+			}
+
+			var stopped bool
+			resourceVersion, stopped = c.watchEvents(watcher, filter, resourceVersion, events, stop)
+			if stopped {
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// watchEvents reads all events from the provided watcher until the watch is closed or the stop channel is closed.
+// All received events are also send to the sink of the client.
+// It returns the resource version to resume the watch and if the watch was stopped by the stop channel.
+func (c *Client) watchEvents(watcher watch.Interface, filter Filter, resourceVersion string, events chan<- Event, stop <-chan struct{}) (string, bool) {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return resourceVersion, true
+		case result, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, false
+			}
+
+			switch result.Type {
+			case watch.Added, watch.Modified:
+				item, ok := result.Object.(*v1.Event)
+				if !ok {
+					continue
+				}
+
+				resourceVersion = item.ResourceVersion
+				event := convertEvent(*item)
+				if c.sink != nil {
+					c.sink.Send([]Event{event})
+				}
+
+				if !matchEvent(filter, event) {
+					continue
+				}
+
+				select {
+				case <-stop:
+					return resourceVersion, true
+				case events <- event:
+				}
+			case watch.Error:
+				// The resource version is too old, so that we have to start a new watch with the current resource version.
+				err := apierrors.FromObject(result.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return "", false
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrInvalidConfig is thrown if a value in the configuration file is not valid.
+	ErrInvalidConfig = errors.New("invalid configuration")
+)
+
+// Views contains the names of the views, which can be used as keys for the per view settings and as default view.
+var Views = []string{"pods", "nodes", "events"}
+
+// Statuses maps the names of the pod statuses, which can be used in the default filter, to the status of the api filter.
+var Statuses = map[string]int{"": 10, "Running": 2, "Waiting": 1, "Terminated": 0}
+
+// Config represents the configuration file for kubetop.
+// The columns are configured per view, the key of the map is the name of the view ('pods', 'nodes' or 'events').
+// The custom columns are additional columns for the pods and nodes view, which can be used in the columns section like the built-in columns.
+// The keybindings map the name of an action to the keys which trigger the action. The theme is the name of a built-in theme or of a theme from the themes section.
+// The clipboard templates are configured per view and define the text, which is copied to the clipboard for the selected object. The hooks are external commands, which can be run for the selected object.
+type Config struct {
+	Defaults      Defaults            `json:"defaults"`
+	Keybindings   map[string][]string `json:"keybindings"`
+	Theme         string              `json:"theme"`
+	Themes        map[string]Theme    `json:"themes"`
+	Columns       map[string][]Column `json:"columns"`
+	CustomColumns CustomColumns       `json:"customColumns"`
+	Clipboard     map[string]string   `json:"clipboard"`
+	Hooks         []Hook              `json:"hooks"`
+}
+
+// Defaults contains the settings which are used when kubetop is started.
+// The sortorder is configured per view, because each view has its own columns. The refresh interval is a duration like '2s' or '1m'.
+type Defaults struct {
+	View            string              `json:"view"`
+	Sort            map[string]api.Sort `json:"sort"`
+	Filter          Filter              `json:"filter"`
+	RefreshInterval string              `json:"refreshInterval"`
+}
+
+// Filter represents the default filter in the configuration file.
+// In contrast to the api filter the status is the name of the status (e.g. 'Running'), so that an unset status means no filter.
+type Filter struct {
+	Namespace string `json:"namespace"`
+	Node      string `json:"node"`
+	Status    string `json:"status"`
+	EventType string `json:"eventType"`
+	Reason    string `json:"reason"`
+	Selector  string `json:"selector"`
+}
+
+// Theme represents a custom theme in the configuration file.
+// Each style has a foreground and a background color. Colors are the name of a basic color (e.g. 'green') or the number of a color in the 256 color palette.
+type Theme struct {
+	Header    Style `json:"header"`
+	Cursor    Style `json:"cursor"`
+	Statusbar Style `json:"statusbar"`
+	List      Style `json:"list"`
+	Marked    Style `json:"marked"`
+}
+
+// Hook represents an external command, which can be run for the selected object (e.g. 'kubectl describe').
+// The command is a template, which is filled with the fields of the selected object. If no views are set, the hook can be used in all views. The key is optional, because all hooks can be run via the ':run' command.
+// If wait is set, kubetop waits for '<Enter>' after the command finished, so that the output of the command can be read.
+type Hook struct {
+	Name    string   `json:"name"`
+	Key     string   `json:"key"`
+	Views   []string `json:"views"`
+	Command string   `json:"command"`
+	Wait    bool     `json:"wait"`
+}
+
+// Style represents the foreground and background color of a part of the user interface.
+type Style struct {
+	Fg string `json:"fg"`
+	Bg string `json:"bg"`
+}
+
+// CustomColumns contains the custom columns for the pods and nodes view.
+type CustomColumns struct {
+	Pods  []api.CustomColumn `json:"pods"`
+	Nodes []api.CustomColumn `json:"nodes"`
+}
+
+// Column represents a column of a table view in the configuration file.
+// The order of the columns in the configuration file is the order in which they are rendered. Columns which are not listed are hidden.
+// If the width is zero, the default width of the column is used.
+type Column struct {
+	Name  string `json:"name"`
+	Width int    `json:"width"`
+}
+
+// DefaultPath returns the path of the configuration file in the XDG config directory.
+// This is '$XDG_CONFIG_HOME/kubetop/config.yaml' or '~/.config/kubetop/config.yaml' if the XDG_CONFIG_HOME environment variable is not set.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "kubetop", "config.yaml")
+}
+
+// Load reads the configuration from the provided file.
+// If no file is provided we use the file from the XDG config directory. If this file does not exist an empty configuration is returned, so that the defaults for all settings are used.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = DefaultPath()
+		if _, err := os.Stat(path); path == "" || os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// validate checks the values of the configuration, which can not be checked while the configuration is unmarshaled.
+// The keybindings and the colors of the themes are checked by the user interface, because the actions and colors are defined there.
+func (c *Config) validate() error {
+	if c.Defaults.View != "" && !isView(c.Defaults.View) {
+		return fmt.Errorf("%v: unknown default view %s", ErrInvalidConfig, c.Defaults.View)
+	}
+
+	for view := range c.Defaults.Sort {
+		if !isView(view) {
+			return fmt.Errorf("%v: unknown view %s for the default sortorder", ErrInvalidConfig, view)
+		}
+	}
+
+	if _, ok := Statuses[c.Defaults.Filter.Status]; !ok {
+		return fmt.Errorf("%v: unknown status %s for the default filter", ErrInvalidConfig, c.Defaults.Filter.Status)
+	}
+
+	for view, text := range c.Clipboard {
+		if !isView(view) {
+			return fmt.Errorf("%v: unknown view %s for the clipboard template", ErrInvalidConfig, view)
+		}
+
+		if _, err := template.New(view).Parse(text); err != nil {
+			return fmt.Errorf("%v: %s", ErrInvalidConfig, err.Error())
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, hook := range c.Hooks {
+		if hook.Name == "" || names[hook.Name] {
+			return fmt.Errorf("%v: hooks must have an unique name", ErrInvalidConfig)
+		}
+		names[hook.Name] = true
+
+		for _, view := range hook.Views {
+			if !isView(view) {
+				return fmt.Errorf("%v: unknown view %s for the hook %s", ErrInvalidConfig, view, hook.Name)
+			}
+		}
+
+		if _, err := template.New(hook.Name).Parse(hook.Command); hook.Command == "" || err != nil {
+			return fmt.Errorf("%v: invalid command for the hook %s", ErrInvalidConfig, hook.Name)
+		}
+	}
+
+	if _, err := c.RefreshInterval(); err != nil {
+		return fmt.Errorf("%v: %s", ErrInvalidConfig, err.Error())
+	}
+
+	return nil
+}
+
+// RefreshInterval returns the interval in which the data of the views is refreshed.
+// If no interval is configured, we refresh the data every two seconds.
+func (c *Config) RefreshInterval() (time.Duration, error) {
+	if c.Defaults.RefreshInterval == "" {
+		return 2 * time.Second, nil
+	}
+
+	interval, err := time.ParseDuration(c.Defaults.RefreshInterval)
+	if err != nil {
+		return 0, err
+	}
+
+	if interval <= 0 {
+		return 0, fmt.Errorf("refresh interval must be positive: %s", c.Defaults.RefreshInterval)
+	}
+
+	return interval, nil
+}
+
+// APIFilter returns the default filter as filter for the Kubernetes API.
+func (f Filter) APIFilter() api.Filter {
+	return api.Filter{
+		Namespace: f.Namespace,
+		Node:      f.Node,
+		Status:    Statuses[f.Status],
+		EventType: f.EventType,
+		Reason:    f.Reason,
+		Selector:  f.Selector,
+	}
+}
+
+func isView(view string) bool {
+	for _, v := range Views {
+		if v == view {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"empty", Config{}, false},
+		{"valid defaults", Config{Defaults: Defaults{View: "nodes", Sort: map[string]api.Sort{"pods": api.NewSort("cpu", true)}, Filter: Filter{Status: "Running"}, RefreshInterval: "5s"}}, false},
+		{"unknown default view", Config{Defaults: Defaults{View: "deployments"}}, true},
+		{"unknown sort view", Config{Defaults: Defaults{Sort: map[string]api.Sort{"deployments": api.NewSort("name", false)}}}, true},
+		{"unknown status", Config{Defaults: Defaults{Filter: Filter{Status: "Pending"}}}, true},
+		{"invalid refresh interval", Config{Defaults: Defaults{RefreshInterval: "soon"}}, true},
+		{"negative refresh interval", Config{Defaults: Defaults{RefreshInterval: "-1s"}}, true},
+		{"valid clipboard", Config{Clipboard: map[string]string{"pods": "{{ .Namespace }}/{{ .Name }}"}}, false},
+		{"unknown clipboard view", Config{Clipboard: map[string]string{"deployments": "{{ .Name }}"}}, true},
+		{"invalid clipboard template", Config{Clipboard: map[string]string{"pods": "{{ .Name"}}, true},
+		{"valid hook", Config{Hooks: []Hook{{Name: "describe", Views: []string{"pods"}, Command: "kubectl describe pod {{ .Name }}"}}}, false},
+		{"hook without name", Config{Hooks: []Hook{{Command: "true"}}}, true},
+		{"duplicated hook", Config{Hooks: []Hook{{Name: "a", Command: "true"}, {Name: "a", Command: "true"}}}, true},
+		{"hook with unknown view", Config{Hooks: []Hook{{Name: "a", Views: []string{"deployments"}, Command: "true"}}}, true},
+		{"hook without command", Config{Hooks: []Hook{{Name: "a"}}}, true},
+		{"hook with invalid template", Config{Hooks: []Hook{{Name: "a", Command: "{{ .Name"}}}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRefreshInterval(t *testing.T) {
+	for _, tt := range []struct {
+		interval string
+		want     time.Duration
+	}{
+		{"", 2 * time.Second},
+		{"500ms", 500 * time.Millisecond},
+		{"1m", time.Minute},
+	} {
+		config := Config{Defaults: Defaults{RefreshInterval: tt.interval}}
+		if got, err := config.RefreshInterval(); err != nil || got != tt.want {
+			t.Errorf("%q: expected %s, got %s (%v)", tt.interval, tt.want, got, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+// fileWriter writes the events as JSON lines to a local file.
+// When the file reaches the max size, the file is rotated: The current file is renamed to '<path>.1', an existing '<path>.1' is renamed to '<path>.2' and so on.
+// Only the configured number of backups is kept. If the max size is zero, the file is never rotated.
+type fileWriter struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// newFileWriter opens the file at the provided path. New events are appended to an existing file.
+func newFileWriter(path string, maxSize int64, maxBackups int) (*fileWriter, error) {
+	w := &fileWriter{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// open opens the file and determines its current size.
+func (w *fileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// rotate closes the current file, renames all backups and opens a new file.
+// If the backups could not be renamed, the current file is opened again, so that the events are still written and the rotation is tried again with the next event.
+func (w *fileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if err := w.renameBackups(); err != nil {
+		if openErr := w.open(); openErr != nil {
+			return openErr
+		}
+
+		return err
+	}
+
+	return w.open()
+}
+
+// renameBackups removes the oldest backup and renames the current file and all other backups. Backups which do not exist yet are skipped.
+// If no backups are kept, the current file is removed.
+func (w *fileWriter) renameBackups() error {
+	if w.maxBackups <= 0 {
+		return os.Remove(w.path)
+	}
+
+	if err := os.Remove(fmt.Sprintf("%s.%d", w.path, w.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for i := w.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.Rename(w.path, w.path+".1")
+}
+
+// Write writes each event as single JSON line to the file.
+func (w *fileWriter) Write(events []api.Event) error {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+
+		if w.maxSize > 0 && w.size > 0 && w.size+int64(len(data)) > w.maxSize {
+			if err := w.rotate(); err != nil {
+				return err
+			}
+		}
+
+		n, err := w.file.Write(data)
+		w.size = w.size + int64(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the file.
+func (w *fileWriter) Close() error {
+	return w.file.Close()
+}
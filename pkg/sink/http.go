@@ -0,0 +1,86 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+const (
+	// httpTimeout is the timeout for a single request to the HTTP endpoint.
+	httpTimeout = 10 * time.Second
+	// httpRetryInterval is the time we wait before the first retry. The time is doubled for every further retry.
+	httpRetryInterval = time.Second
+)
+
+// httpWriter sends the events as JSON lines to a HTTP endpoint.
+// Each batch of events is send with one POST request. Failed requests are retried with an exponential backoff.
+type httpWriter struct {
+	url     string
+	retries int
+	client  *http.Client
+}
+
+// newHTTPWriter returns a new writer for the provided HTTP endpoint.
+func newHTTPWriter(url string, retries int) *httpWriter {
+	return &httpWriter{
+		url:     url,
+		retries: retries,
+		client: &http.Client{
+			Timeout: httpTimeout,
+		},
+	}
+}
+
+// Write sends the batch of events to the HTTP endpoint.
+// If the request fails or the endpoint does not return a 2xx status code, the request is retried.
+func (w *httpWriter) Write(events []api.Event) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	interval := httpRetryInterval
+
+	for attempt := 0; attempt <= w.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(interval)
+			interval = interval * 2
+		}
+
+		err = w.send(body.Bytes())
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// send sends a single request to the HTTP endpoint.
+func (w *httpWriter) send(body []byte) error {
+	resp, err := w.client.Post(w.url, "application/x-ndjson", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Close does nothing, because there is no open connection to the HTTP endpoint.
+func (w *httpWriter) Close() error {
+	return nil
+}
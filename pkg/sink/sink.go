@@ -0,0 +1,257 @@
+package sink
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+var (
+	// ErrInvalidTarget is thrown if the target of the sink is empty.
+	ErrInvalidTarget = errors.New("invalid sink target")
+)
+
+const (
+	// seenRetention is the time after which an event is removed from the deduplication, when it was not received again.
+	// The Kubernetes API server removes events after one hour by default, so an event which was not received for two hours is most likely deleted.
+	seenRetention = 2 * time.Hour
+	// maxPending is the maximum number of events, which are kept while the target is not reachable. When more events are pending, the oldest events are dropped.
+	maxPending = 10000
+)
+
+// Options contains the settings for a sink.
+// The max size and max backups are only used for files, the retries are only used for HTTP endpoints.
+type Options struct {
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxSize       int64
+	MaxBackups    int
+	Retries       int
+}
+
+// writer is the interface for the different targets of a sink.
+// The events are written as batch, so that a HTTP endpoint receives multiple events with one request.
+type writer interface {
+	Write(events []api.Event) error
+	Close() error
+}
+
+// Sink receives all events seen by the API client and exports them to a file or a HTTP endpoint.
+// The events are deduplicated by their uid and count, so that an event is only exported again, when it was fired again.
+// The events are collected in batches, which are written when the batch size is reached or when the flush interval expires.
+// Sending events never blocks, so that a slow target does not block the user interface.
+// Events which could not be written are written again with the next batch. Errors of the target are collected and can be shown to the user via Err.
+type Sink struct {
+	writer  writer
+	options Options
+
+	seen     map[string]seenEvent
+	pending  []api.Event
+	closed   bool
+	err      error
+	reported bool
+	mutex    sync.Mutex
+
+	flush chan struct{}
+	done  chan struct{}
+}
+
+// seenEvent is the count of an exported event and the time when the event was received the last time.
+type seenEvent struct {
+	count    int32
+	received time.Time
+}
+
+// New returns a new sink for the provided target.
+// If the target starts with 'http://' or 'https://' the events are send to the HTTP endpoint, otherwise the target is used as path for a local file.
+// The file can also be specified with the 'file://' prefix.
+func New(target string, options Options) (*Sink, error) {
+	var w writer
+	var err error
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1
+	}
+
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		w = newHTTPWriter(target, options.Retries)
+	} else {
+		target = strings.TrimPrefix(target, "file://")
+		if target == "" {
+			return nil, ErrInvalidTarget
+		}
+
+		w, err = newFileWriter(target, options.MaxSize, options.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := &Sink{
+		writer:  w,
+		options: options,
+		seen:    make(map[string]seenEvent),
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Send adds all events, which were not exported before, to the pending events.
+// An event is exported again, when the count of the event was changed.
+func (s *Sink) Send(events []api.Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		seen, ok := s.seen[event.UID]
+		s.seen[event.UID] = seenEvent{event.Count, now}
+		if ok && seen.count == event.Count {
+			continue
+		}
+
+		s.pending = append(s.pending, event)
+	}
+	s.limitPending()
+
+	if len(s.pending) >= s.options.BatchSize {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close writes all pending events and closes the target of the sink.
+func (s *Sink) Close() error {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+
+	close(s.flush)
+	<-s.done
+
+	return s.writer.Close()
+}
+
+// Err returns the last error, which occurred while the events were written to the target.
+// An error is only returned once until the events were written successfully again, so that a failing target is not reported on every refresh.
+func (s *Sink) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err == nil || s.reported {
+		return nil
+	}
+
+	s.reported = true
+	return s.err
+}
+
+// run writes the pending events in batches to the target, when the batch size is reached or the flush interval expires.
+// Errors do not stop the sink, they are returned by Err. The HTTP writer retries failed requests on its own.
+// Events which were not received for some time are removed from the deduplication, so that the memory usage does not grow for the whole lifetime of kubetop.
+func (s *Sink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case _, ok := <-s.flush:
+			s.write()
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+			s.write()
+			s.evict()
+		}
+	}
+}
+
+// write takes all pending events and writes them in batches to the target.
+// When a batch could not be written, the batch and all following events are added to the pending events again, so that they are written with the next flush.
+func (s *Sink) write() {
+	s.mutex.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mutex.Unlock()
+
+	for len(pending) > 0 {
+		size := len(pending)
+		if size > s.options.BatchSize {
+			size = s.options.BatchSize
+		}
+
+		err := s.writer.Write(pending[:size])
+
+		s.mutex.Lock()
+		s.setErr(err)
+		if err != nil {
+			s.pending = append(pending, s.pending...)
+			s.limitPending()
+			s.mutex.Unlock()
+			return
+		}
+		s.mutex.Unlock()
+
+		pending = pending[size:]
+	}
+}
+
+// setErr saves the error of the last write. An error is kept until it was returned by Err, also when a later write was successful, so that no failure is missed.
+// The mutex of the sink must be held by the caller.
+func (s *Sink) setErr(err error) {
+	if err != nil {
+		if s.err == nil {
+			s.reported = false
+		}
+		s.err = err
+	} else if s.reported {
+		s.err = nil
+	}
+}
+
+// limitPending drops the oldest pending events, when there are more than the maximum number of pending events.
+// The dropped events are removed from the deduplication, so that they are exported when they are received again.
+// The mutex of the sink must be held by the caller.
+func (s *Sink) limitPending() {
+	if len(s.pending) <= maxPending {
+		return
+	}
+
+	dropped := len(s.pending) - maxPending
+	for _, event := range s.pending[:dropped] {
+		delete(s.seen, event.UID)
+	}
+	s.pending = append([]api.Event(nil), s.pending[dropped:]...)
+}
+
+// evict removes all events from the deduplication, which were not received within the seen retention.
+func (s *Sink) evict() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for uid, seen := range s.seen {
+		if time.Since(seen.received) > seenRetention {
+			delete(s.seen, uid)
+		}
+	}
+}
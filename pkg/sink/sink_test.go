@@ -0,0 +1,189 @@
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+// fakeWriter collects all written events and returns the configured error.
+type fakeWriter struct {
+	events []api.Event
+	err    error
+}
+
+func (w *fakeWriter) Write(events []api.Event) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.events = append(w.events, events...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func newTestSink(w writer) *Sink {
+	return &Sink{
+		writer:  w,
+		options: Options{BatchSize: 100, FlushInterval: time.Second},
+		seen:    make(map[string]seenEvent),
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+func TestSendDeduplicates(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		batches [][]api.Event
+		want    int
+	}{
+		{"new events", [][]api.Event{{{UID: "a", Count: 1}, {UID: "b", Count: 1}}}, 2},
+		{"same event twice", [][]api.Event{{{UID: "a", Count: 1}}, {{UID: "a", Count: 1}}}, 1},
+		{"count changed", [][]api.Event{{{UID: "a", Count: 1}}, {{UID: "a", Count: 2}}}, 2},
+		{"count changed back", [][]api.Event{{{UID: "a", Count: 1}}, {{UID: "a", Count: 2}}, {{UID: "a", Count: 2}}}, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &fakeWriter{}
+			s := newTestSink(w)
+
+			for _, batch := range tt.batches {
+				s.Send(batch)
+			}
+			s.write()
+
+			if len(w.events) != tt.want {
+				t.Errorf("expected %d exported events, got %d", tt.want, len(w.events))
+			}
+		})
+	}
+}
+
+func TestEvict(t *testing.T) {
+	s := newTestSink(&fakeWriter{})
+	s.seen["old"] = seenEvent{1, time.Now().Add(-seenRetention - time.Minute)}
+	s.seen["new"] = seenEvent{1, time.Now()}
+
+	s.evict()
+
+	if _, ok := s.seen["old"]; ok {
+		t.Errorf("expected old event to be evicted")
+	}
+	if _, ok := s.seen["new"]; !ok {
+		t.Errorf("expected new event to be kept")
+	}
+}
+
+func TestErr(t *testing.T) {
+	w := &fakeWriter{err: errors.New("write failed")}
+	s := newTestSink(w)
+
+	s.Send([]api.Event{{UID: "a", Count: 1}})
+	s.write()
+	if err := s.Err(); err == nil {
+		t.Fatalf("expected error after failed write")
+	}
+
+	s.Send([]api.Event{{UID: "b", Count: 1}})
+	s.write()
+	if err := s.Err(); err != nil {
+		t.Errorf("expected error to be reported only once, got %v", err)
+	}
+
+	w.err = nil
+	s.Send([]api.Event{{UID: "c", Count: 1}})
+	s.write()
+	if err := s.Err(); err != nil {
+		t.Errorf("expected no error after successful write, got %v", err)
+	}
+
+	w.err = errors.New("write failed again")
+	s.Send([]api.Event{{UID: "d", Count: 1}})
+	s.write()
+	if err := s.Err(); err == nil {
+		t.Errorf("expected new error after successful write")
+	}
+}
+
+func TestWriteFailure(t *testing.T) {
+	w := &fakeWriter{err: errors.New("write failed")}
+	s := newTestSink(w)
+	s.options.BatchSize = 1
+
+	s.Send([]api.Event{{UID: "a", Count: 1}, {UID: "b", Count: 1}})
+	s.write()
+	if len(s.pending) != 2 {
+		t.Fatalf("expected 2 pending events after failed write, got %d", len(s.pending))
+	}
+
+	w.err = nil
+	s.Send([]api.Event{{UID: "a", Count: 1}, {UID: "c", Count: 1}})
+	s.write()
+	if len(w.events) != 3 {
+		t.Errorf("expected 3 exported events, got %d", len(w.events))
+	}
+	if err := s.Err(); err == nil {
+		t.Errorf("expected error of failed write to be reported after successful write")
+	}
+}
+
+func TestPendingLimit(t *testing.T) {
+	s := newTestSink(&fakeWriter{err: errors.New("write failed")})
+
+	events := make([]api.Event, maxPending+10)
+	for i := range events {
+		events[i] = api.Event{UID: fmt.Sprintf("event-%d", i), Count: 1}
+	}
+	s.Send(events)
+	s.write()
+
+	if len(s.pending) != maxPending {
+		t.Errorf("expected %d pending events, got %d", maxPending, len(s.pending))
+	}
+	if s.pending[0].UID != "event-10" {
+		t.Errorf("expected oldest events to be dropped, got %s", s.pending[0].UID)
+	}
+	if _, ok := s.seen["event-0"]; ok {
+		t.Errorf("expected dropped event to be removed from the deduplication")
+	}
+}
+
+func TestRotateError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubetop-sink")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.json")
+	if err := os.MkdirAll(filepath.Join(path+".1", "backup"), 0755); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	w, err := newFileWriter(path, 1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer w.Close()
+
+	if err := w.Write([]api.Event{{UID: "a"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := w.Write([]api.Event{{UID: "b"}}); err == nil {
+		t.Errorf("expected error when the backup could not be removed")
+	}
+
+	os.RemoveAll(path + ".1")
+	if err := w.Write([]api.Event{{UID: "c"}}); err != nil {
+		t.Errorf("expected file to be written after the failed rotation, got %v", err)
+	}
+}
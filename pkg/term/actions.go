@@ -0,0 +1,422 @@
+package term
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// render renders all widgets of the user interface.
+// Rendering is blocked while termui is closed to run a hook.
+func (t *Term) render() {
+	t.suspend.Lock()
+	defer t.suspend.Unlock()
+
+	ui.Clear()
+	t.tabbar.SetTabs(t.tabLabels(), t.activeTab)
+	if table, _ := t.focusedTable(); table != nil {
+		t.statusbar.SetMarked(len(table.MarkedKeys()))
+	} else {
+		t.statusbar.SetMarked(0)
+	}
+	t.statusbar.SetReplay(t.replayText())
+	ui.Render(append(append([]ui.Drawable{t.view}, t.splitDrawables()...), t.tabbar, t.summary, t.breadcrumb, t.statusbar, t.list, t.picker, t.help, t.prompt)...)
+}
+
+// setView replaces the current view with the provided view.
+// The statusbar, the cluster summary and the breadcrumbs are updated for the new view. Use openView or switchView instead, to also update the navigation history.
+func (t *Term) setView(view widgets.View, viewType widgets.ViewType) {
+	t.view = view
+	t.ViewType = viewType
+	t.statusbar.SetViewType(t.ViewType)
+	t.summary.SetViewType(t.ViewType)
+	t.statusbar.SetSortAndFilter(view.Sortorder(), view.Filter())
+	t.statusbar.SetPause(view.Pause())
+	t.breadcrumb.SetPath(t.breadcrumbs())
+	t.tabbar.SetTabs(t.tabLabels(), t.activeTab)
+	t.resize(t.termWidth, t.termHeight)
+}
+
+// setSortAndFilter sets the sortorder and the filter of the current view and updates the data of the view.
+func (t *Term) setSortAndFilter(sortorder api.Sort, filter api.Filter) {
+	t.view.SetSortAndFilter(sortorder, filter)
+	t.statusbar.SetSortAndFilter(sortorder, filter)
+	t.view.Update()
+}
+
+// showList shows the list of the provided type.
+func (t *Term) showList(listType widgets.ListType) {
+	t.listType = listType
+	t.listActive = t.list.Show(t.ViewType, t.listType, t.view.Filter().Namespace, t.termWidth, t.termHeight)
+}
+
+// hideList hides the list.
+func (t *Term) hideList() {
+	t.list.Hide()
+	t.listActive = false
+}
+
+// resize changes the size of all widgets to the new size of the terminal.
+func (t *Term) resize(termWidth, termHeight int) {
+	t.termWidth, t.termHeight = termWidth, termHeight
+
+	// The view is rendered below the tab bar, the cluster summary and the breadcrumbs, when they are visible.
+	top := 0
+	if t.tabbar.Visible() {
+		t.tabbar.SetRect(0, 0, t.termWidth, widgets.TabBarHeight)
+		top = widgets.TabBarHeight
+	} else {
+		t.tabbar.SetRect(0, 0, 0, 0)
+	}
+
+	t.summary.SetRect(0, top, t.termWidth, top+widgets.SummaryHeight)
+	if t.summary.Visible(t.ViewType) {
+		top = top + widgets.SummaryHeight
+	}
+	if t.breadcrumb.Visible() {
+		t.breadcrumb.SetRect(0, top, t.termWidth, top+widgets.BreadcrumbHeight)
+		top = top + widgets.BreadcrumbHeight
+	} else {
+		t.breadcrumb.SetRect(0, 0, 0, 0)
+	}
+
+	if t.splitActive() {
+		t.layoutSplit(top)
+	} else {
+		t.view.SetRect(0, top, t.termWidth, t.termHeight)
+	}
+	t.statusbar.SetRect(0, t.termHeight-1, t.termWidth, t.termHeight)
+	if t.prompt.Active() {
+		t.prompt.SetRect(0, t.termHeight-1, t.termWidth, t.termHeight)
+	}
+}
+
+// click handles a click, which was not translated to an action.
+// A click outside of the list closes the list.
+// A click on the header of a table sorts the table by the column, a second click on the same column toggles the direction of the sortorder.
+// A click on a row selects the row. In the pod details view we have to update the view to load the logs for the selected container.
+// A click on a tab of the tab bar shows the tab. In the split layout a click into a pane also focuses the pane.
+func (t *Term) click(mouse ui.Mouse) {
+	if t.listActive {
+		t.hideList()
+	} else if index, ok := t.tabbar.TabAt(mouse.X, mouse.Y); ok {
+		t.selectTab(index)
+	} else if t.splitActive() && t.split.view != nil && image.Pt(mouse.X, mouse.Y).In(t.split.view.GetRect()) {
+		t.split.focused = true
+		if v, ok := t.split.view.(clickable); ok && v.HandleClick(mouse.X, mouse.Y) && t.split.viewType == widgets.ViewTypePodDetails {
+			t.split.view.Update()
+		}
+		t.resize(t.termWidth, t.termHeight)
+	} else if t.splitActive() && t.split.focused {
+		t.split.focused = false
+		t.resize(t.termWidth, t.termHeight)
+		t.click(mouse)
+	} else if column, ok := viewTable(t.view).HeaderColumnAt(mouse.X, mouse.Y); ok {
+		t.setSortAndFilter(t.view.Sortorder().SortBy(column), t.view.Filter())
+	} else if v, ok := t.view.(clickable); ok && v.HandleClick(mouse.X, mouse.Y) {
+		if t.ViewType == widgets.ViewTypePodDetails {
+			t.view.Update()
+		}
+	}
+}
+
+// actionHelp shows the help with all keys, which can be used in the current view.
+func (t *Term) actionHelp() {
+	t.help.Show("Help: "+string(t.ViewType), t.helpRows(), t.termWidth, t.termHeight)
+}
+
+// actionCommand opens the prompt for a command.
+func (t *Term) actionCommand() {
+	t.prompt.Show(":", t.termWidth, t.termHeight)
+}
+
+// showError shows the provided error in the prompt, e.g. when an action fails.
+func (t *Term) showError(err error) {
+	t.prompt.Show(":", t.termWidth, t.termHeight)
+	t.prompt.SetMessage(err.Error(), true)
+}
+
+// reportErrors shows the errors of the event sink and the recorder in the prompt, so that the user knows that events are not exported or recorded anymore.
+// While the prompt is used, the error is kept until the prompt is closed, so that the input of the user is not replaced.
+func (t *Term) reportErrors() {
+	if t.prompt.Active() {
+		return
+	}
+
+	if t.Sink != nil {
+		if err := t.Sink.Err(); err != nil {
+			t.showError(fmt.Errorf("event sink: %v", err))
+			return
+		}
+	}
+
+	if t.Recorder != nil {
+		if err := t.Recorder.Err(); err != nil {
+			t.showError(fmt.Errorf("recording: %v", err))
+		}
+	}
+}
+
+// actionQuit quits kubetop after the current event was handled.
+func (t *Term) actionQuit() {
+	t.quit = true
+}
+
+// actionUp selects the previous entry of the list or row of the focused view.
+// If the pod details view is focused we need to update the view, because we need to load the logs for the selected container.
+func (t *Term) actionUp() {
+	if t.listActive {
+		t.list.ScrollUp()
+		return
+	}
+
+	view, viewType := t.focused()
+	view.SelectPrev()
+	if viewType == widgets.ViewTypePodDetails {
+		view.Update()
+	}
+}
+
+// actionDown selects the next entry of the list or row of the focused view.
+func (t *Term) actionDown() {
+	if t.listActive {
+		t.list.ScrollDown()
+		return
+	}
+
+	view, viewType := t.focused()
+	view.SelectNext()
+	if viewType == widgets.ViewTypePodDetails {
+		view.Update()
+	}
+}
+
+// actionTop selects the first row of the focused view.
+func (t *Term) actionTop() {
+	view, _ := t.focused()
+	view.SelectTop()
+}
+
+// actionBottom selects the last row of the focused view.
+func (t *Term) actionBottom() {
+	view, _ := t.focused()
+	view.SelectBottom()
+}
+
+// actionHalfPageDown scrolls the focused view half a page down.
+func (t *Term) actionHalfPageDown() {
+	view, _ := t.focused()
+	view.SelectHalfPageDown()
+}
+
+// actionHalfPageUp scrolls the focused view half a page up.
+func (t *Term) actionHalfPageUp() {
+	view, _ := t.focused()
+	view.SelectHalfPageUp()
+}
+
+// actionPageDown scrolls the focused view a page down.
+func (t *Term) actionPageDown() {
+	view, _ := t.focused()
+	view.SelectPageDown()
+}
+
+// actionPageUp scrolls the focused view a page up.
+func (t *Term) actionPageUp() {
+	view, _ := t.focused()
+	view.SelectPageUp()
+}
+
+// actionSelect applies the selected entry of the list or opens the selected row of the view.
+// For nodes we show all pods running on the node, for pods and events the details view is opened. In the event details view we go to the involved object of the event.
+func (t *Term) actionSelect() {
+	if t.listActive {
+		viewType, sortorder, filter := t.list.Selected(t.ViewType, t.listType, t.view.Sortorder(), t.view.Filter())
+		t.listActive = false
+
+		if viewType != t.ViewType {
+			filter := api.Filter{Namespace: "", Node: "", Status: 10}
+			t.switchView(t.newView(viewType, filter, t.defaultSortorder(viewType), t.termWidth, t.termHeight), viewType)
+		} else {
+			t.view.SetSortAndFilter(sortorder, filter)
+			t.statusbar.SetSortAndFilter(sortorder, filter)
+		}
+
+		t.view.Update()
+		return
+	}
+
+	// If the table of the view is empty, there is no row which could be opened.
+	if t.view.SelectedValues() == nil {
+		return
+	}
+
+	switch t.ViewType {
+	case widgets.ViewTypeNodes:
+		selectedRow := t.view.SelectedValues()
+		nodeFilter := t.view.Filter()
+		nodeFilter.Node = selectedRow[0]
+
+		t.openView(t.newView(widgets.ViewTypePods, nodeFilter, t.defaultSortorder(widgets.ViewTypePods), t.termWidth, t.termHeight), widgets.ViewTypePods, viewLabel(widgets.ViewTypePods, nodeFilter))
+	case widgets.ViewTypePods:
+		selectedRow := t.view.SelectedValues()
+		t.openView(widgets.NewPodDetailsWidget(selectedRow[1], selectedRow[0], t.APIClient, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypePodDetails, "Pod "+selectedRow[0]+"/"+selectedRow[1])
+	case widgets.ViewTypeEvents:
+		// If the selected row is a group of events, we expand or collapse the group instead of showing the details.
+		if events, ok := t.view.(*widgets.EventsWidget); !ok || !events.ToggleGroup() {
+			selectedRow := t.view.SelectedValues()
+			t.openView(widgets.NewEventDetailsWidget(selectedRow[4], selectedRow[3], t.APIClient, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypeEventDetails, "Event "+selectedRow[3]+"/"+selectedRow[4])
+		}
+	case widgets.ViewTypeEventDetails:
+		if involvedView, viewType, label := t.involvedObjectView(t.view.SelectedValues(), t.termWidth, t.termHeight); involvedView != nil {
+			t.openView(involvedView, viewType, label)
+		}
+	}
+
+	t.view.Update()
+}
+
+// actionBack closes the list or goes back to the previous view of the navigation history.
+// If there is no previous view, we go back from the details views to a new pods or events view.
+func (t *Term) actionBack() {
+	if t.listActive {
+		t.hideList()
+		return
+	}
+
+	if !t.previousView() {
+		switch t.ViewType {
+		case widgets.ViewTypePodDetails:
+			t.switchView(t.newView(widgets.ViewTypePods, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypePods)
+		case widgets.ViewTypeEventDetails:
+			t.switchView(t.newView(widgets.ViewTypeEvents, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypeEvents)
+		}
+	}
+
+	t.view.Update()
+}
+
+// actionFocus switches the focus between the containers and events of the pod details view.
+func (t *Term) actionFocus() {
+	view, _ := t.focused()
+	view.ToggleFocus()
+}
+
+// actionPause pauses or resumes updating the data of the current view.
+func (t *Term) actionPause() {
+	t.view.TogglePause()
+	t.statusbar.SetPause(t.view.Pause())
+	t.view.Update()
+}
+
+// actionInvolvedObject opens the view for the involved object of the selected event.
+func (t *Term) actionInvolvedObject() {
+	if involvedView, viewType, label := t.involvedObjectView(t.view.SelectedValues(), t.termWidth, t.termHeight); involvedView != nil {
+		t.openView(involvedView, viewType, label)
+		t.view.Update()
+	}
+}
+
+// actionGroupEvents toggles the grouping of the events view.
+func (t *Term) actionGroupEvents() {
+	if events, ok := t.view.(*widgets.EventsWidget); ok {
+		events.ToggleGrouped()
+		t.view.Update()
+	}
+}
+
+// actionTail toggles the tail mode of the events view.
+func (t *Term) actionTail() {
+	if events, ok := t.view.(*widgets.EventsWidget); ok {
+		events.ToggleTail()
+		t.view.Update()
+	}
+}
+
+// actionGaugeMode cycles through the gauge modes for the cpu and memory columns.
+// The mode is kept when the view is changed.
+func (t *Term) actionGaugeMode() {
+	t.gaugeMode = t.gaugeMode.Next()
+	setGaugeMode(t.view, t.gaugeMode)
+	t.view.Update()
+}
+
+// setGaugeMode sets the gauge mode of the nodes and pods view.
+func setGaugeMode(view widgets.View, gaugeMode widgets.GaugeMode) {
+	switch v := view.(type) {
+	case *widgets.NodesWidget:
+		v.GaugeMode = gaugeMode
+	case *widgets.PodsWidget:
+		v.GaugeMode = gaugeMode
+	}
+}
+
+// actionColumns shows the column picker for the table of the current view.
+func (t *Term) actionColumns() {
+	t.picker.Show(viewTable(t.view), t.termWidth, t.termHeight)
+}
+
+// actionSort shows the list to select the primary sort column.
+func (t *Term) actionSort() {
+	t.showList(widgets.ListTypeSort)
+}
+
+// actionSortSecondary shows the list to select the secondary sort column.
+func (t *Term) actionSortSecondary() {
+	t.showList(widgets.ListTypeSortSecondary)
+}
+
+// actionSortReverse reverses the direction of the primary sort column.
+func (t *Term) actionSortReverse() {
+	t.setSortAndFilter(t.view.Sortorder().Reverse(), t.view.Filter())
+}
+
+// actionSortPrevious sorts the table by the previous visible column.
+func (t *Term) actionSortPrevious() {
+	sortorder := t.view.Sortorder()
+	t.setSortAndFilter(sortorder.SortBy(adjacentSortColumn(viewTable(t.view), sortorder.Primary.Column, -1)), t.view.Filter())
+}
+
+// actionSortNext sorts the table by the next visible column.
+func (t *Term) actionSortNext() {
+	sortorder := t.view.Sortorder()
+	t.setSortAndFilter(sortorder.SortBy(adjacentSortColumn(viewTable(t.view), sortorder.Primary.Column, 1)), t.view.Filter())
+}
+
+// actionFilterNamespace shows the list to filter the view by namespace.
+func (t *Term) actionFilterNamespace() {
+	t.showList(widgets.ListTypeFilterNamespace)
+}
+
+// actionFilterNode shows the list to filter the view by node.
+func (t *Term) actionFilterNode() {
+	t.showList(widgets.ListTypeFilterNode)
+}
+
+// actionFilterStatus shows the status filter for pods and the event type filter for events.
+func (t *Term) actionFilterStatus() {
+	if t.ViewType == widgets.ViewTypeEvents {
+		t.showList(widgets.ListTypeFilterEventType)
+	} else {
+		t.showList(widgets.ListTypeFilterStatus)
+	}
+}
+
+// actionFilterReason shows the list to filter the events by reason.
+func (t *Term) actionFilterReason() {
+	t.showList(widgets.ListTypeFilterReason)
+}
+
+// actionAlerts shows the list of the active alerts.
+func (t *Term) actionAlerts() {
+	t.showList(widgets.ListTypeAlerts)
+}
+
+// actionViews shows the list to switch to another view.
+func (t *Term) actionViews() {
+	t.showList(widgets.ListTypeView)
+}
@@ -0,0 +1,188 @@
+package term
+
+import (
+	"strings"
+
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+// binding represents an action of kubetop in the keybinding registry.
+// The action is the name which is used in the keybindings section of the configuration file and the keys are the default keys of the action.
+// The description is shown in the help for the views in which the action can be used. If no views are set, the action can be used in all views.
+// The list description is shown in the help while a list is shown. Actions without a list description can not be used while a list is shown.
+type binding struct {
+	action          string
+	keys            []string
+	description     string
+	listDescription string
+	views           []widgets.ViewType
+	handler         func(t *Term)
+}
+
+var (
+	tableViews    = []widgets.ViewType{widgets.ViewTypeNodes, widgets.ViewTypePods, widgets.ViewTypeEvents}
+	filterViews   = []widgets.ViewType{widgets.ViewTypePods, widgets.ViewTypeEvents}
+	scrollViews   = []widgets.ViewType{widgets.ViewTypeNodes, widgets.ViewTypePods, widgets.ViewTypePodDetails, widgets.ViewTypeEvents}
+	selectViews   = []widgets.ViewType{widgets.ViewTypeNodes, widgets.ViewTypePods, widgets.ViewTypeEvents, widgets.ViewTypeEventDetails}
+	eventsViews   = []widgets.ViewType{widgets.ViewTypeEvents, widgets.ViewTypeEventDetails}
+	resourceViews = []widgets.ViewType{widgets.ViewTypeNodes, widgets.ViewTypePods}
+)
+
+// bindings is the keybinding registry of kubetop.
+// All key events are dispatched through the registry and the help is generated from the registry, so that the help always shows the keys which can be used.
+// The order of the bindings is the order in which they are shown in the help.
+var bindings []binding
+
+// We have to initialize the registry in the init function, because the help handler uses the registry, which would be an initialization cycle otherwise.
+func init() {
+	bindings = []binding{
+		{"help", []string{"?"}, "Show help", "Show help", nil, (*Term).actionHelp},
+		{"command", []string{":"}, "Enter a command (e.g. ':ns kube-system')", "", nil, (*Term).actionCommand},
+		{"quit", []string{"q"}, "Quit", "Quit", nil, (*Term).actionQuit},
+		{"up", []string{"k", "<Up>"}, "Select previous row", "Select previous entry", scrollViews, (*Term).actionUp},
+		{"down", []string{"j", "<Down>"}, "Select next row", "Select next entry", scrollViews, (*Term).actionDown},
+		{"top", []string{"<Home>", "gg"}, "Select first row", "", scrollViews, (*Term).actionTop},
+		{"bottom", []string{"G", "<End>"}, "Select last row", "", scrollViews, (*Term).actionBottom},
+		{"halfPageDown", []string{"<C-d>"}, "Scroll half page down", "", scrollViews, (*Term).actionHalfPageDown},
+		{"halfPageUp", []string{"<C-u>"}, "Scroll half page up", "", scrollViews, (*Term).actionHalfPageUp},
+		{"pageDown", []string{"<C-f>"}, "Scroll page down", "", scrollViews, (*Term).actionPageDown},
+		{"pageUp", []string{"<C-b>"}, "Scroll page up", "", scrollViews, (*Term).actionPageUp},
+		{"select", []string{"<Enter>"}, "Open the selected row", "Apply the selected entry", selectViews, (*Term).actionSelect},
+		{"back", []string{"<Escape>"}, "Go back to the previous view", "Close the list", nil, (*Term).actionBack},
+		{"focus", []string{"<Tab>"}, "Switch focus between containers and events", "", []widgets.ViewType{widgets.ViewTypePodDetails}, (*Term).actionFocus},
+		{"split", []string{"|"}, "Toggle split layout with the details of the selected row", "", tableViews, (*Term).actionSplit},
+		{"splitFocus", []string{"w"}, "Switch focus between the panes of the split layout", "", tableViews, (*Term).actionSplitFocus},
+		{"splitShrink", []string{"["}, "Shrink the left pane of the split layout", "", tableViews, (*Term).actionSplitShrink},
+		{"splitGrow", []string{"]"}, "Grow the left pane of the split layout", "", tableViews, (*Term).actionSplitGrow},
+		{"search", []string{"/"}, "Search rows", "", tableViews, (*Term).actionSearch},
+		{"searchNext", []string{"n"}, "Select next match of the search", "", tableViews, (*Term).actionSearchNext},
+		{"searchPrevious", []string{"N"}, "Select previous match of the search", "", tableViews, (*Term).actionSearchPrevious},
+		{"mark", []string{"<Space>"}, "Mark or unmark the selected row", "", tableViews, (*Term).actionMark},
+		{"markMatches", []string{"*"}, "Mark all matches of the search", "", tableViews, (*Term).actionMarkMatches},
+		{"clearMarks", []string{"U"}, "Unmark all rows", "", tableViews, (*Term).actionClearMarks},
+		{"delete", []string{"D"}, "Delete the marked or selected pods", "", []widgets.ViewType{widgets.ViewTypePods}, (*Term).actionDelete},
+		{"yank", []string{"y"}, "Copy the selected object to the clipboard", "", nil, (*Term).actionYank},
+		{"copyNames", []string{"Y"}, "Copy the names of the marked or selected rows", "", resourceViews, (*Term).actionCopyNames},
+		{"pause", []string{"p"}, "Pause updating data", "Pause updating data", nil, (*Term).actionPause},
+		{"involvedObject", []string{"o"}, "Go to the involved object", "", eventsViews, (*Term).actionInvolvedObject},
+		{"groupEvents", []string{"a"}, "Toggle grouping of events", "", []widgets.ViewType{widgets.ViewTypeEvents}, (*Term).actionGroupEvents},
+		{"tail", []string{"t"}, "Toggle tail mode", "", []widgets.ViewType{widgets.ViewTypeEvents}, (*Term).actionTail},
+		{"gaugeMode", []string{"b"}, "Switch between bars, percentages and absolute values", "", resourceViews, (*Term).actionGaugeMode},
+		{"columns", []string{"c"}, "Show column picker", "", tableViews, (*Term).actionColumns},
+		{"sort", []string{"<F1>"}, "Show sortorder", "", tableViews, (*Term).actionSort},
+		{"sortSecondary", []string{"s"}, "Show secondary sortorder", "", tableViews, (*Term).actionSortSecondary},
+		{"sortReverse", []string{"r"}, "Reverse sortorder", "", tableViews, (*Term).actionSortReverse},
+		{"sortPrevious", []string{"<"}, "Sort by previous column", "", tableViews, (*Term).actionSortPrevious},
+		{"sortNext", []string{">"}, "Sort by next column", "", tableViews, (*Term).actionSortNext},
+		{"filterNamespace", []string{"<F2>"}, "Show namespace filter", "", filterViews, (*Term).actionFilterNamespace},
+		{"filterNode", []string{"<F3>"}, "Show node filter", "", filterViews, (*Term).actionFilterNode},
+		{"filterStatus", []string{"<F4>"}, "Show status / event type filter", "", filterViews, (*Term).actionFilterStatus},
+		{"filterReason", []string{"<F5>"}, "Show reason filter", "", []widgets.ViewType{widgets.ViewTypeEvents}, (*Term).actionFilterReason},
+		{"newTab", []string{"<C-t>"}, "Open a new tab", "", nil, (*Term).actionNewTab},
+		{"closeTab", []string{"<C-w>"}, "Close the tab", "", nil, (*Term).actionCloseTab},
+		{"nextTab", []string{"gt"}, "Show the next tab", "", nil, (*Term).actionNextTab},
+		{"previousTab", []string{"gT"}, "Show the previous tab", "", nil, (*Term).actionPreviousTab},
+		{"alerts", []string{"!"}, "Show firing alerts", "", nil, (*Term).actionAlerts},
+		{"views", []string{"v"}, "Select view", "", nil, (*Term).actionViews},
+		{"replayPlay", []string{"P"}, "Play or stop the replay", "", nil, (*Term).actionReplayPlay},
+		{"replaySlower", []string{"-"}, "Halve the speed of the replay", "", nil, (*Term).actionReplaySlower},
+		{"replayFaster", []string{"+"}, "Double the speed of the replay", "", nil, (*Term).actionReplayFaster},
+		{"replayBackward", []string{"<Left>"}, "Move the replay 30 seconds backward", "", nil, (*Term).actionReplayBackward},
+		{"replayForward", []string{"<Right>"}, "Move the replay 30 seconds forward", "", nil, (*Term).actionReplayForward},
+	}
+}
+
+// findBinding returns the binding for the provided action.
+func findBinding(action string) (binding, bool) {
+	for _, b := range bindings {
+		if b.action == action {
+			return b, true
+		}
+	}
+
+	return binding{}, false
+}
+
+// defaultAction returns the action for one of the default keys.
+// This is used for the items of the statusbar, which always show the default keys (e.g. '[F1]').
+func defaultAction(key string) (string, bool) {
+	for _, b := range bindings {
+		for _, k := range b.keys {
+			if k == key {
+				return b.action, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// enabled returns true if the binding can be used in the provided view and list state.
+func (b binding) enabled(viewType widgets.ViewType, listActive bool) bool {
+	if listActive {
+		return b.listDescription != ""
+	}
+
+	if b.views == nil {
+		return true
+	}
+
+	for _, v := range b.views {
+		if v == viewType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bindingEnabled returns true if the binding can be used in the current view or in the focused pane of the split layout.
+// The actions to control a replay can only be used, when a recording is replayed.
+func (t *Term) bindingEnabled(b binding) bool {
+	if strings.HasPrefix(b.action, replayActionPrefix) && t.Replay == nil {
+		return false
+	}
+
+	_, focusedType := t.focused()
+	return b.enabled(t.ViewType, t.listActive) || b.enabled(focusedType, t.listActive)
+}
+
+// dispatch runs the handler of the action, if the action can be used in the current view and list state.
+// Actions of hooks are run for the selected object, when no list is shown. It returns false if the action is unknown or can not be used.
+func (t *Term) dispatch(action string) bool {
+	if strings.HasPrefix(action, hookActionPrefix) {
+		if t.listActive {
+			return false
+		}
+
+		t.runHookAction(action)
+		return true
+	}
+
+	b, ok := findBinding(action)
+	if !ok || !t.bindingEnabled(b) {
+		return false
+	}
+
+	b.handler(t)
+	return true
+}
+
+// helpRows returns the rows for the help, which contain the keys and descriptions of all bindings, which can be used in the current view and list state.
+func (t *Term) helpRows() [][]string {
+	var rows [][]string
+	for _, b := range bindings {
+		if !t.bindingEnabled(b) || len(t.keys.keys[b.action]) == 0 {
+			continue
+		}
+
+		description := b.description
+		if t.listActive {
+			description = b.listDescription
+		}
+
+		rows = append(rows, []string{strings.Join(t.keys.keys[b.action], ", "), description})
+	}
+
+	return append(rows, t.hookHelpRows()...)
+}
@@ -0,0 +1,54 @@
+package term
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+)
+
+// copyToClipboard copies the provided text to the clipboard of the terminal.
+// We use the OSC 52 escape sequence, so that the clipboard also works when kubetop is running on a remote machine via ssh. The terminal must support the escape sequence.
+func copyToClipboard(text string) error {
+	_, err := fmt.Fprintf(os.Stdout, "\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
+
+// kindViews maps the kind of the selected object to the view, which is used as key for the clipboard templates in the configuration file.
+var kindViews = map[string]string{
+	"Pod":   "pods",
+	"Node":  "nodes",
+	"Event": "events",
+}
+
+// yankText returns the text, which is copied to the clipboard for the provided object.
+// If a template is configured for the view of the object, the template is used. Otherwise we copy '<namespace>/<name>' or only the name for objects without a namespace.
+func (t *Term) yankText(obj object) (string, error) {
+	if t.Config != nil {
+		if text, ok := t.Config.Clipboard[kindViews[obj.Kind]]; ok && text != "" {
+			return executeTemplate(text, obj)
+		}
+	}
+
+	if obj.Namespace == "" {
+		return obj.Name, nil
+	}
+
+	return obj.Namespace + "/" + obj.Name, nil
+}
+
+// actionYank copies the selected object of the focused view to the clipboard. Errors are shown in the prompt.
+func (t *Term) actionYank() {
+	obj, ok := t.selectedObject()
+	if !ok {
+		return
+	}
+
+	text, err := t.yankText(obj)
+	if err == nil {
+		err = copyToClipboard(text)
+	}
+
+	if err != nil {
+		t.showError(err)
+	}
+}
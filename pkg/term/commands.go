@@ -0,0 +1,424 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/config"
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+var (
+	// ErrUnknownCommand is thrown if the command entered in the prompt does not exist.
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrInvalidArgument is thrown if the argument of a command is missing or not valid.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrNotSupported is thrown if a command can not be used in the current view.
+	ErrNotSupported = errors.New("not supported in this view")
+)
+
+// command represents a command, which can be entered in the prompt.
+// The usage and the description are shown by the help command. The complete function returns the candidates for the completion of the argument, it is optional.
+type command struct {
+	name        string
+	aliases     []string
+	usage       string
+	description string
+	complete    func(t *Term) []string
+	handler     func(t *Term, args []string) error
+}
+
+// commands contains all commands, which can be entered in the prompt.
+// We have to initialize the commands in the init function, because the help command uses the commands, which would be an initialization cycle otherwise.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"pods", []string{"po"}, "pods", "Show the pods view", nil, commandView(widgets.ViewTypePods)},
+		{"nodes", []string{"no"}, "nodes", "Show the nodes view", nil, commandView(widgets.ViewTypeNodes)},
+		{"events", []string{"ev"}, "events", "Show the events view", nil, commandView(widgets.ViewTypeEvents)},
+		{"ns", []string{"namespace"}, "ns <namespace|->", "Filter by namespace", completeNamespaces, commandNamespace},
+		{"node", nil, "node <node|->", "Filter by node", completeNodes, commandNode},
+		{"status", nil, "status <status|->", "Filter pods by status or events by type", completeStatuses, commandStatus},
+		{"selector", nil, "selector <selector|->", "Filter pods by a label selector", nil, commandSelector},
+		{"reason", nil, "reason <reason|->", "Filter events by reason", completeReasons, commandReason},
+		{"sort", nil, "sort <column> [asc|desc]", "Sort by a column", completeColumns, commandSort},
+		{"pause", nil, "pause", "Pause updating data", nil, commandPause},
+		{"pod", nil, "pod <name|namespace/name>", "Go to a pod", completePods, commandPod},
+		{"delete", nil, "delete", "Delete the marked or selected pods", nil, commandDelete},
+		{"label", nil, "label <key=value|key->...", "Label the marked or selected pods or nodes", nil, commandLabel},
+		{"export", nil, "export <file>", "Export the marked or selected rows as CSV", nil, commandExport},
+		{"copy", nil, "copy", "Copy the names of the marked or selected pods or nodes", nil, commandCopy},
+		{"run", nil, "run <hook>", "Run a command from the hooks section of the configuration file", completeHooks, commandRun},
+		{"seek", nil, "seek <+/-duration|duration|15:04:05>", "Move the replay to a position", nil, commandSeek},
+		{"help", nil, "help", "Show all commands", nil, commandHelp},
+		{"quit", []string{"q"}, "quit", "Quit", nil, commandQuit},
+	}
+}
+
+// findCommand returns the command with the provided name or alias.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+
+		for _, alias := range c.aliases {
+			if alias == name {
+				return c, true
+			}
+		}
+	}
+
+	return command{}, false
+}
+
+// execute runs the command from the provided input of the prompt.
+func (t *Term) execute(input string) error {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	c, ok := findCommand(fields[0])
+	if !ok {
+		return fmt.Errorf("%v: %s", ErrUnknownCommand, fields[0])
+	}
+
+	return c.handler(t, fields[1:])
+}
+
+// complete returns the completed input and the candidates, when the input could not be completed unambiguously.
+// The first word of the input is completed with the names of the commands, all other words with the candidates of the command.
+func (t *Term) complete(input string) (string, []string) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || (len(fields) == 1 && !strings.HasSuffix(input, " ")) {
+		var names []string
+		for _, c := range commands {
+			names = append(names, c.name)
+		}
+
+		var prefix string
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+
+		completed, candidates := completeWord(prefix, names)
+		if len(candidates) == 1 {
+			return completed + " ", nil
+		}
+
+		return completed, candidates
+	}
+
+	c, ok := findCommand(fields[0])
+	if !ok || c.complete == nil {
+		return input, nil
+	}
+
+	var prefix string
+	if !strings.HasSuffix(input, " ") {
+		prefix = fields[len(fields)-1]
+	}
+
+	completed, candidates := completeWord(prefix, c.complete(t))
+	return strings.TrimSuffix(input, prefix) + completed, candidates
+}
+
+// completeWord returns the longest common prefix of all candidates, which start with the provided prefix, and the matching candidates.
+func completeWord(prefix string, candidates []string) (string, []string) {
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	if len(matches) == 0 {
+		return prefix, nil
+	}
+
+	common := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, common) {
+			common = common[:len(common)-1]
+		}
+	}
+
+	sort.Strings(matches)
+	return common, matches
+}
+
+// argument returns the first argument of a command. The argument '-' is used to remove a filter, so we return an empty string for it.
+func argument(args []string, usage string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%v: usage %s", ErrInvalidArgument, usage)
+	}
+
+	if args[0] == "-" {
+		return "", nil
+	}
+
+	return args[0], nil
+}
+
+// filterView returns an error if the current view is not one of the provided views.
+func (t *Term) filterView(views ...widgets.ViewType) error {
+	for _, v := range views {
+		if v == t.ViewType {
+			return nil
+		}
+	}
+
+	return ErrNotSupported
+}
+
+func commandView(viewType widgets.ViewType) func(t *Term, args []string) error {
+	return func(t *Term, args []string) error {
+		filter := api.Filter{Namespace: "", Node: "", Status: 10}
+		t.switchView(t.newView(viewType, filter, t.defaultSortorder(viewType), t.termWidth, t.termHeight), viewType)
+		t.view.Update()
+		return nil
+	}
+}
+
+func commandNamespace(t *Term, args []string) error {
+	if err := t.filterView(filterViews...); err != nil {
+		return err
+	}
+
+	namespace, err := argument(args, "ns <namespace|->")
+	if err != nil {
+		return err
+	}
+
+	filter := t.view.Filter()
+	filter.Namespace = namespace
+	t.setSortAndFilter(t.view.Sortorder(), filter)
+	return nil
+}
+
+func commandNode(t *Term, args []string) error {
+	if err := t.filterView(filterViews...); err != nil {
+		return err
+	}
+
+	node, err := argument(args, "node <node|->")
+	if err != nil {
+		return err
+	}
+
+	filter := t.view.Filter()
+	filter.Node = node
+	t.setSortAndFilter(t.view.Sortorder(), filter)
+	return nil
+}
+
+// commandStatus filters pods by their status and events by their type.
+func commandStatus(t *Term, args []string) error {
+	if err := t.filterView(filterViews...); err != nil {
+		return err
+	}
+
+	status, err := argument(args, "status <status|->")
+	if err != nil {
+		return err
+	}
+
+	filter := t.view.Filter()
+	if t.ViewType == widgets.ViewTypeEvents {
+		if status != "" && status != "Normal" && status != "Warning" {
+			return fmt.Errorf("%v: unknown event type %s", ErrInvalidArgument, status)
+		}
+
+		filter.EventType = status
+	} else {
+		value, ok := config.Statuses[status]
+		if !ok {
+			return fmt.Errorf("%v: unknown status %s", ErrInvalidArgument, status)
+		}
+
+		filter.Status = value
+	}
+
+	t.setSortAndFilter(t.view.Sortorder(), filter)
+	return nil
+}
+
+// commandSelector filters the pods by a label selector. The selector is joined from all arguments, so that selectors like 'app in (a, b)' can be used.
+func commandSelector(t *Term, args []string) error {
+	if err := t.filterView(widgets.ViewTypePods); err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("%v: usage selector <selector|->", ErrInvalidArgument)
+	}
+
+	selector := strings.Join(args, " ")
+	if selector == "-" {
+		selector = ""
+	}
+
+	filter := t.view.Filter()
+	filter.Selector = selector
+	t.setSortAndFilter(t.view.Sortorder(), filter)
+	return nil
+}
+
+func commandReason(t *Term, args []string) error {
+	if err := t.filterView(widgets.ViewTypeEvents); err != nil {
+		return err
+	}
+
+	reason, err := argument(args, "reason <reason|->")
+	if err != nil {
+		return err
+	}
+
+	filter := t.view.Filter()
+	filter.Reason = reason
+	t.setSortAndFilter(t.view.Sortorder(), filter)
+	return nil
+}
+
+// commandSort sorts the view by the provided column. Without a direction the direction is toggled, when the view is already sorted by the column.
+func commandSort(t *Term, args []string) error {
+	if err := t.filterView(tableViews...); err != nil {
+		return err
+	}
+
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%v: usage sort <column> [asc|desc]", ErrInvalidArgument)
+	}
+
+	var known bool
+	for _, column := range viewTable(t.view).Columns {
+		if column.Name == args[0] {
+			known = true
+		}
+	}
+
+	if !known {
+		return fmt.Errorf("%v: unknown column %s", ErrInvalidArgument, args[0])
+	}
+
+	sortorder := t.view.Sortorder().SortBy(args[0])
+	if len(args) == 2 {
+		switch args[1] {
+		case "asc":
+			sortorder.Primary.Desc = false
+		case "desc":
+			sortorder.Primary.Desc = true
+		default:
+			return fmt.Errorf("%v: unknown direction %s", ErrInvalidArgument, args[1])
+		}
+	}
+
+	t.setSortAndFilter(sortorder, t.view.Filter())
+	return nil
+}
+
+func commandPause(t *Term, args []string) error {
+	t.actionPause()
+	return nil
+}
+
+// commandPod goes to the pod with the provided name.
+// In the pods view we select the pod in the table, the pod is found by its key '<namespace>/<name>' or by its name. Otherwise the pod is opened in the pod details view, therefore we need the namespace of the pod from the argument or the namespace filter.
+func commandPod(t *Term, args []string) error {
+	name, err := argument(args, "pod <name|namespace/name>")
+	if err != nil || name == "" {
+		return fmt.Errorf("%v: usage pod <name|namespace/name>", ErrInvalidArgument)
+	}
+
+	namespace := t.view.Filter().Namespace
+	if parts := strings.SplitN(name, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+		if pods, ok := t.view.(*widgets.PodsWidget); ok && pods.SelectKey(namespace+"/"+name) {
+			return nil
+		}
+	} else if pods, ok := t.view.(*widgets.PodsWidget); ok {
+		if pods.SelectValue(1, name) {
+			return nil
+		}
+	}
+
+	if namespace == "" {
+		return fmt.Errorf("%v: pod %s not found, use namespace/name", ErrInvalidArgument, name)
+	}
+
+	t.openView(widgets.NewPodDetailsWidget(name, namespace, t.APIClient, api.Filter{Namespace: namespace, Node: "", Status: 10}, t.defaultSortorder(widgets.ViewTypePods), t.termWidth, t.termHeight), widgets.ViewTypePodDetails, "Pod "+namespace+"/"+name)
+	t.view.Update()
+	return nil
+}
+
+// commandHelp shows the usage and the description of all commands in the help.
+func commandHelp(t *Term, args []string) error {
+	var rows [][]string
+	for _, c := range commands {
+		usage := c.usage
+		if len(c.aliases) > 0 {
+			usage = usage + " (" + strings.Join(c.aliases, ", ") + ")"
+		}
+
+		rows = append(rows, []string{":" + usage, c.description})
+	}
+
+	t.help.Show("Commands", rows, t.termWidth, t.termHeight)
+	return nil
+}
+
+func commandQuit(t *Term, args []string) error {
+	t.quit = true
+	return nil
+}
+
+func completeNamespaces(t *Term) []string {
+	namespaces, _ := t.APIClient.GetNamespaces()
+	return namespaces
+}
+
+func completeNodes(t *Term) []string {
+	nodes, _ := t.APIClient.GetNodes()
+	return nodes
+}
+
+func completeStatuses(t *Term) []string {
+	if t.ViewType == widgets.ViewTypeEvents {
+		return []string{"-", "Normal", "Warning"}
+	}
+
+	return []string{"-", "Running", "Waiting", "Terminated"}
+}
+
+func completeReasons(t *Term) []string {
+	reasons, _ := t.APIClient.GetEventReasons(t.view.Filter().Namespace)
+	return reasons
+}
+
+func completeColumns(t *Term) []string {
+	var columns []string
+	if table := viewTable(t.view); table != nil {
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	return columns
+}
+
+// completePods returns the names of the pods in the current rows of the pods view.
+func completePods(t *Term) []string {
+	var names []string
+	if pods, ok := t.view.(*widgets.PodsWidget); ok {
+		for _, row := range pods.Rows {
+			names = append(names, row[1])
+		}
+	}
+
+	return names
+}
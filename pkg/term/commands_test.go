@@ -0,0 +1,33 @@
+package term
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteWord(t *testing.T) {
+	candidates := []string{"kube-system", "kube-public", "default", "kube-node-lease"}
+
+	for _, tt := range []struct {
+		name           string
+		prefix         string
+		wantCompleted  string
+		wantCandidates []string
+	}{
+		{"empty prefix", "", "", []string{"default", "kube-node-lease", "kube-public", "kube-system"}},
+		{"common prefix", "k", "kube-", []string{"kube-node-lease", "kube-public", "kube-system"}},
+		{"single match", "kube-s", "kube-system", []string{"kube-system"}},
+		{"exact match", "default", "default", []string{"default"}},
+		{"no match", "monitoring", "monitoring", nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			completed, matches := completeWord(tt.prefix, candidates)
+			if completed != tt.wantCompleted {
+				t.Errorf("expected completion %q, got %q", tt.wantCompleted, completed)
+			}
+			if !reflect.DeepEqual(matches, tt.wantCandidates) {
+				t.Errorf("expected candidates %v, got %v", tt.wantCandidates, matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package term
+
+import (
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+// historyEntry is a view in the navigation history.
+// We keep the widget of the view, so that the filter, the sortorder, the selected row and the scroll position are restored when we go back to the view.
+type historyEntry struct {
+	view     widgets.View
+	viewType widgets.ViewType
+	label    string
+}
+
+// viewLabel returns the label of the nodes, pods and events view, which is shown in the breadcrumbs.
+// If the pods view is filtered by a node, the node is added to the label.
+func viewLabel(viewType widgets.ViewType, filter api.Filter) string {
+	switch viewType {
+	case widgets.ViewTypeNodes:
+		return "Nodes"
+	case widgets.ViewTypeEvents:
+		return "Events"
+	case widgets.ViewTypePods:
+		if filter.Node != "" {
+			return "Pods on " + filter.Node
+		}
+
+		return "Pods"
+	}
+
+	return string(viewType)
+}
+
+// openView opens the provided view on top of the current view.
+// The current view is added to the navigation history, so that we can go back to it with the 'back' action.
+func (t *Term) openView(view widgets.View, viewType widgets.ViewType, label string) {
+	t.history = append(t.history, historyEntry{t.view, t.ViewType, t.label})
+	t.label = label
+	t.setView(view, viewType)
+}
+
+// switchView replaces the current view with the provided view and clears the navigation history.
+// It is used when another top level view is selected, e.g. via the views list or the ':pods' command.
+func (t *Term) switchView(view widgets.View, viewType widgets.ViewType) {
+	t.closeViews()
+	t.history = nil
+	t.label = viewLabel(viewType, view.Filter())
+	t.setView(view, viewType)
+}
+
+// previousView restores the last view of the navigation history. If the history is empty false is returned.
+// Column changes which were done via the column picker after the view was left are applied to the restored view, the same for the gauge mode.
+func (t *Term) previousView() bool {
+	if len(t.history) == 0 {
+		return false
+	}
+
+	entry := t.history[len(t.history)-1]
+	t.history = t.history[:len(t.history)-1]
+	t.view.Close()
+
+	if table := viewTable(entry.view); table != nil {
+		if columns, ok := t.columns[entry.viewType]; ok {
+			table.Columns = widgets.CopyColumns(columns)
+		}
+	}
+	setGaugeMode(entry.view, t.gaugeMode)
+
+	t.label = entry.label
+	t.setView(entry.view, entry.viewType)
+	return true
+}
+
+// closeViews closes the current view and all views of the navigation history, when they are not used anymore.
+func (t *Term) closeViews() {
+	t.view.Close()
+	for _, entry := range t.history {
+		entry.view.Close()
+	}
+}
+
+// breadcrumbs returns the labels of all views in the navigation history and the label of the current view.
+func (t *Term) breadcrumbs() []string {
+	var path []string
+	for _, entry := range t.history {
+		path = append(path, entry.label)
+	}
+
+	return append(path, t.label)
+}
@@ -0,0 +1,153 @@
+package term
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ricoberger/kubetop/pkg/config"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+var (
+	// ErrUnknownHook is thrown if no hook with the provided name is configured.
+	ErrUnknownHook = errors.New("unknown hook")
+)
+
+// hookActionPrefix is the prefix of the actions in the keymap, which run a hook instead of an action of the keybinding registry.
+const hookActionPrefix = "hook:"
+
+// hooks returns the hooks from the configuration file.
+func (t *Term) hooks() []config.Hook {
+	if t.Config == nil {
+		return nil
+	}
+
+	return t.Config.Hooks
+}
+
+// findHook returns the hook with the provided name.
+func (t *Term) findHook(name string) (config.Hook, bool) {
+	for _, hook := range t.hooks() {
+		if hook.Name == name {
+			return hook, true
+		}
+	}
+
+	return config.Hook{}, false
+}
+
+// hookEnabled returns true if the hook can be used for the provided object. The pod and event details views use the hooks of the pods and events view.
+func hookEnabled(hook config.Hook, obj object) bool {
+	if len(hook.Views) == 0 {
+		return true
+	}
+
+	for _, view := range hook.Views {
+		if view == kindViews[obj.Kind] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runHook runs the hook with the provided name for the selected object.
+// While the command is running termui is closed, so that the command can use the terminal. Rendering is blocked until termui is initialized again, afterwards the current view is rendered for the new size of the terminal.
+func (t *Term) runHook(name string) error {
+	hook, ok := t.findHook(name)
+	if !ok {
+		return fmt.Errorf("%v: %s", ErrUnknownHook, name)
+	}
+
+	obj, ok := t.selectedObject()
+	if !ok || !hookEnabled(hook, obj) {
+		return ErrNotSupported
+	}
+
+	command, err := executeTemplate(hook.Command, obj)
+	if err != nil {
+		return err
+	}
+
+	t.suspend.Lock()
+	defer t.suspend.Unlock()
+
+	ui.Close()
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+
+	if hook.Wait {
+		fmt.Fprint(os.Stdout, "\nPress <Enter> to return to kubetop")
+		bufio.NewReader(os.Stdin).ReadString('\n')
+	}
+
+	if initErr := ui.Init(); initErr != nil {
+		t.quit = true
+		return initErr
+	}
+
+	t.resize(ui.TerminalDimensions())
+	if err != nil {
+		return fmt.Errorf("%s: %v", hook.Name, err)
+	}
+
+	return nil
+}
+
+// runHookAction runs the hook for an action of the keymap. Errors are shown in the prompt.
+func (t *Term) runHookAction(action string) {
+	if err := t.runHook(strings.TrimPrefix(action, hookActionPrefix)); err != nil {
+		t.showError(err)
+	}
+}
+
+// hookHelpRows returns the rows for the help, which contain the keys and names of the hooks, which can be used for the selected object.
+func (t *Term) hookHelpRows() [][]string {
+	obj, ok := t.selectedObject()
+	if !ok || t.listActive {
+		return nil
+	}
+
+	var rows [][]string
+	for _, hook := range t.hooks() {
+		if hook.Key != "" && hookEnabled(hook, obj) && t.keys.actions[hook.Key] == hookActionPrefix+hook.Name {
+			rows = append(rows, []string{hook.Key, "Run " + hook.Name})
+		}
+	}
+
+	return rows
+}
+
+// commandRun runs a hook from the configuration file.
+func commandRun(t *Term, args []string) error {
+	name, err := argument(args, "run <hook>")
+	if err != nil || name == "" {
+		return fmt.Errorf("%v: usage run <hook>", ErrInvalidArgument)
+	}
+
+	return t.runHook(name)
+}
+
+// completeHooks returns the names of the hooks, which can be used for the selected object.
+func completeHooks(t *Term) []string {
+	obj, ok := t.selectedObject()
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, hook := range t.hooks() {
+		if hookEnabled(hook, obj) {
+			names = append(names, hook.Name)
+		}
+	}
+
+	return names
+}
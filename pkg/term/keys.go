@@ -0,0 +1,92 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ricoberger/kubetop/pkg/config"
+)
+
+var (
+	// ErrUnknownAction is thrown if the keybindings of the configuration file contain an unknown action.
+	ErrUnknownAction = errors.New("unknown action")
+)
+
+// keymap maps the keys pressed by the user to the actions of the keybinding registry and the actions back to their keys.
+// The keys of an action are the default keys of the binding or the keys from the configuration file.
+type keymap struct {
+	actions map[string]string
+	keys    map[string][]string
+}
+
+// newKeymap returns the keymap for the provided keybindings from the configuration file.
+// When an action is configured, the configured keys replace the default keys of the action. If a key is used by multiple actions, the configured action wins, so that the default keys can be used for other actions.
+func newKeymap(configured map[string][]string) (keymap, error) {
+	k := keymap{
+		actions: make(map[string]string),
+		keys:    make(map[string][]string),
+	}
+
+	for action := range configured {
+		if _, ok := findBinding(action); !ok {
+			return k, fmt.Errorf("%v: %s", ErrUnknownAction, action)
+		}
+	}
+
+	for _, b := range bindings {
+		if _, ok := configured[b.action]; !ok {
+			for _, key := range b.keys {
+				k.actions[key] = b.action
+			}
+		}
+	}
+
+	for _, b := range bindings {
+		for _, key := range configured[b.action] {
+			k.actions[key] = b.action
+		}
+	}
+
+	// Collect the keys of each action in the order of the registry, so that the help shows the keys in the same order as they were configured.
+	for _, b := range bindings {
+		keys := b.keys
+		if configuredKeys, ok := configured[b.action]; ok {
+			keys = configuredKeys
+		}
+
+		for _, key := range keys {
+			if k.actions[key] == b.action {
+				k.keys[b.action] = append(k.keys[b.action], key)
+			}
+		}
+	}
+
+	return k, nil
+}
+
+// addHooks binds the keys of the hooks from the configuration file. The key of a hook replaces the action, which is bound to the key.
+func (k keymap) addHooks(hooks []config.Hook) {
+	for _, hook := range hooks {
+		if hook.Key == "" {
+			continue
+		}
+
+		if action, ok := k.actions[hook.Key]; ok {
+			var keys []string
+			for _, key := range k.keys[action] {
+				if key != hook.Key {
+					keys = append(keys, key)
+				}
+			}
+			k.keys[action] = keys
+		}
+
+		k.actions[hook.Key] = hookActionPrefix + hook.Name
+	}
+}
+
+// action returns the action, which is bound to the provided key.
+func (k keymap) action(key string) (string, bool) {
+	action, ok := k.actions[key]
+	return action, ok
+}
@@ -0,0 +1,83 @@
+package term
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ricoberger/kubetop/pkg/config"
+)
+
+func TestNewKeymap(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		configured  map[string][]string
+		wantActions map[string]string
+		wantKeys    map[string][]string
+		wantErr     bool
+	}{
+		{
+			"default keys", nil,
+			map[string]string{"q": "quit", "k": "up", "<Up>": "up", "w": "splitFocus"},
+			map[string][]string{"quit": {"q"}, "up": {"k", "<Up>"}},
+			false,
+		},
+		{
+			"configured keys replace default keys", map[string][]string{"up": {"i"}},
+			map[string]string{"i": "up", "k": "", "<Up>": ""},
+			map[string][]string{"up": {"i"}},
+			false,
+		},
+		{
+			"configured action wins over default keys", map[string][]string{"up": {"w", "<Up>"}},
+			map[string]string{"w": "up", "<Up>": "up"},
+			map[string][]string{"up": {"w", "<Up>"}, "splitFocus": nil},
+			false,
+		},
+		{
+			"configured actions with the same key", map[string][]string{"up": {"x", "k"}, "down": {"x", "j"}},
+			map[string]string{"x": "down", "k": "up", "j": "down"},
+			map[string][]string{"up": {"k"}, "down": {"x", "j"}},
+			false,
+		},
+		{
+			"unknown action", map[string][]string{"jump": {"J"}},
+			nil, nil,
+			true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := newKeymap(tt.configured)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+
+			for key, want := range tt.wantActions {
+				if got, _ := k.action(key); got != want {
+					t.Errorf("expected key %s to trigger %q, got %q", key, want, got)
+				}
+			}
+
+			for action, want := range tt.wantKeys {
+				if got := k.keys[action]; !reflect.DeepEqual(got, want) {
+					t.Errorf("expected keys %v for %s, got %v", want, action, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAddHooks(t *testing.T) {
+	k, err := newKeymap(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	k.addHooks([]config.Hook{{Name: "describe", Key: "k"}, {Name: "logs"}})
+
+	if got, _ := k.action("k"); got != hookActionPrefix+"describe" {
+		t.Errorf("expected key k to trigger the hook, got %q", got)
+	}
+	if got := k.keys["up"]; !reflect.DeepEqual(got, []string{"<Up>"}) {
+		t.Errorf("expected hook key to be removed from the action, got %v", got)
+	}
+}
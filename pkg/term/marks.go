@@ -0,0 +1,265 @@
+package term
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+var (
+	// ErrNoMatch is thrown if no row of the view matches the search.
+	ErrNoMatch = errors.New("no match")
+)
+
+// focusedTable returns the table of the focused pane. If the focused view is not a table view nil is returned.
+func (t *Term) focusedTable() (*widgets.Table, widgets.ViewType) {
+	view, viewType := t.focused()
+	return viewTable(view), viewType
+}
+
+// targets returns the keys of the rows to which a bulk action is applied. These are the marked rows of the focused view or the selected row, when no rows are marked.
+func (t *Term) targets() ([]string, widgets.ViewType, error) {
+	table, viewType := t.focusedTable()
+	if table == nil {
+		return nil, viewType, ErrNotSupported
+	}
+
+	if keys := table.MarkedKeys(); len(keys) > 0 {
+		return keys, viewType, nil
+	}
+
+	if key := table.RowKey(table.SelectedRow); key != "" {
+		return []string{key}, viewType, nil
+	}
+
+	return nil, viewType, fmt.Errorf("%v: no rows selected", ErrInvalidArgument)
+}
+
+// search selects the next (step 1) or previous (step -1) row of the focused view, which matches the last search.
+func (t *Term) search(step int) error {
+	table, _ := t.focusedTable()
+	if table == nil || t.query == "" {
+		return nil
+	}
+
+	if !table.SelectMatch(t.query, step) {
+		return fmt.Errorf("%v: %s", ErrNoMatch, t.query)
+	}
+
+	return nil
+}
+
+// splitKey splits the key of a pod into the namespace and the name of the pod.
+func splitKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", key
+	}
+
+	return parts[0], parts[1]
+}
+
+// actionSearch opens the prompt for a search.
+func (t *Term) actionSearch() {
+	t.prompt.Show("/", t.termWidth, t.termHeight)
+}
+
+// actionSearchNext selects the next match of the last search.
+func (t *Term) actionSearchNext() {
+	t.search(1)
+}
+
+// actionSearchPrevious selects the previous match of the last search.
+func (t *Term) actionSearchPrevious() {
+	t.search(-1)
+}
+
+// actionMark marks the selected row and selects the next row, so that multiple rows can be marked by pressing the key repeatedly.
+func (t *Term) actionMark() {
+	if table, _ := t.focusedTable(); table != nil {
+		table.ToggleMark()
+		table.ScrollDown()
+	}
+}
+
+// actionMarkMatches marks all rows, which match the last search.
+func (t *Term) actionMarkMatches() {
+	if table, _ := t.focusedTable(); table != nil {
+		table.MarkMatches(t.query)
+	}
+}
+
+// actionClearMarks unmarks all rows of the focused table.
+func (t *Term) actionClearMarks() {
+	if table, _ := t.focusedTable(); table != nil {
+		table.ClearMarks()
+	}
+}
+
+// actionDelete opens the prompt with the delete command, so that the deletion must be confirmed with '<Enter>'.
+func (t *Term) actionDelete() {
+	t.prompt.Show(":", t.termWidth, t.termHeight)
+	t.prompt.SetInput("delete")
+}
+
+// actionCopyNames copies the names of the marked or selected rows to the clipboard.
+func (t *Term) actionCopyNames() {
+	if err := commandCopy(t, nil); err != nil {
+		t.showError(err)
+	}
+}
+
+// commandDelete deletes the marked pods or the selected pod.
+// All pods are deleted, also when the deletion of a pod fails. The first error is returned together with the number of pods which could not be deleted.
+func commandDelete(t *Term, args []string) error {
+	keys, viewType, err := t.targets()
+	if err != nil {
+		return err
+	}
+
+	if viewType != widgets.ViewTypePods {
+		return ErrNotSupported
+	}
+
+	var failed int
+	var firstErr error
+	for _, key := range keys {
+		namespace, name := splitKey(key)
+		if err := t.APIClient.DeletePod(name, namespace); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	table, _ := t.focusedTable()
+	table.ClearMarks()
+	t.updateFocused()
+
+	if firstErr != nil {
+		return fmt.Errorf("could not delete %d of %d pods: %v", failed, len(keys), firstErr)
+	}
+
+	return nil
+}
+
+// commandLabel sets and removes labels of the marked pods or nodes or of the selected pod or node.
+func commandLabel(t *Term, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%v: usage label <key=value|key->...", ErrInvalidArgument)
+	}
+
+	labels, err := api.ParseLabels(args)
+	if err != nil {
+		return err
+	}
+
+	keys, viewType, err := t.targets()
+	if err != nil {
+		return err
+	}
+
+	if viewType != widgets.ViewTypePods && viewType != widgets.ViewTypeNodes {
+		return ErrNotSupported
+	}
+
+	var failed int
+	var firstErr error
+	for _, key := range keys {
+		if viewType == widgets.ViewTypePods {
+			namespace, name := splitKey(key)
+			err = t.APIClient.LabelPod(name, namespace, labels)
+		} else {
+			err = t.APIClient.LabelNode(key, labels)
+		}
+
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	t.updateFocused()
+
+	if firstErr != nil {
+		return fmt.Errorf("could not label %d of %d objects: %v", failed, len(keys), firstErr)
+	}
+
+	return nil
+}
+
+// commandExport writes the visible columns of the marked rows or of the selected row as CSV to the provided file.
+func commandExport(t *Term, args []string) error {
+	file, err := argument(args, "export <file>")
+	if err != nil || file == "" {
+		return fmt.Errorf("%v: usage export <file>", ErrInvalidArgument)
+	}
+
+	table, _ := t.focusedTable()
+	if table == nil {
+		return ErrNotSupported
+	}
+
+	rows := table.MarkedRows()
+	if len(rows) == 0 {
+		if values := table.SelectedRowValues(); values != nil {
+			rows = [][]string{values}
+		}
+	}
+
+	var columns []widgets.Column
+	var header []string
+	for _, column := range table.Columns {
+		if column.Visible {
+			columns = append(columns, column)
+			header = append(header, column.Name)
+		}
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(header)
+	for _, row := range rows {
+		var record []string
+		for _, column := range columns {
+			record = append(record, row[column.Index])
+		}
+		w.Write(record)
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// commandCopy copies the keys of the marked pods or nodes or of the selected pod or node to the clipboard. Each key is written to its own line.
+func commandCopy(t *Term, args []string) error {
+	keys, viewType, err := t.targets()
+	if err != nil {
+		return err
+	}
+
+	if viewType != widgets.ViewTypePods && viewType != widgets.ViewTypeNodes {
+		return ErrNotSupported
+	}
+
+	return copyToClipboard(strings.Join(keys, "\n"))
+}
+
+// updateFocused updates the focused view, e.g. after pods were deleted, so that the changes are shown directly.
+func (t *Term) updateFocused() {
+	view, _ := t.focused()
+	view.Update()
+}
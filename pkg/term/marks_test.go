@@ -0,0 +1,24 @@
+package term
+
+import (
+	"testing"
+)
+
+func TestSplitKey(t *testing.T) {
+	for _, tt := range []struct {
+		key           string
+		wantNamespace string
+		wantName      string
+	}{
+		{"default/nginx", "default", "nginx"},
+		{"kube-system/coredns-5d4b8f", "kube-system", "coredns-5d4b8f"},
+		{"default/a/b", "default", "a/b"},
+		{"node-1", "", "node-1"},
+		{"", "", ""},
+	} {
+		namespace, name := splitKey(tt.key)
+		if namespace != tt.wantNamespace || name != tt.wantName {
+			t.Errorf("%q: expected %q and %q, got %q and %q", tt.key, tt.wantNamespace, tt.wantName, namespace, name)
+		}
+	}
+}
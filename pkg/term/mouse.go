@@ -0,0 +1,55 @@
+package term
+
+import (
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// doubleClickInterval is the maximum time between two clicks at the same position, so that they are handled as double click.
+const doubleClickInterval = 500 * time.Millisecond
+
+// clickable is implemented by all views, where a row can be selected with the mouse.
+type clickable interface {
+	HandleClick(x, y int) bool
+}
+
+// clicks translates mouse clicks to the actions of the keybinding registry.
+// To detect double clicks we remember the time and the position of the last click.
+type clicks struct {
+	last  time.Time
+	mouse ui.Mouse
+}
+
+// action returns the action for a click at the provided position.
+// A click on a row of the shown list selects the row and is handled like the 'select' action. A click on an item of the statusbar is handled like the key of the item (e.g. '<F1>').
+// A double click on a row of the view is also handled like the 'select' action. For all other clicks an empty string is returned and the click is handled by the view.
+func (c *clicks) action(mouse ui.Mouse, view widgets.View, statusbar *widgets.StatusbarWidget, list *widgets.ListWidget, listActive bool) string {
+	if listActive {
+		if list.HandleClick(mouse.X, mouse.Y) {
+			return "select"
+		}
+
+		return ""
+	}
+
+	if key, ok := statusbar.KeyAt(mouse.X, mouse.Y); ok {
+		action, _ := defaultAction(key)
+		return action
+	}
+
+	if time.Since(c.last) < doubleClickInterval && c.mouse.X == mouse.X && c.mouse.Y == mouse.Y {
+		c.last = time.Time{}
+		if v, ok := view.(clickable); ok && v.HandleClick(mouse.X, mouse.Y) {
+			return "select"
+		}
+
+		return ""
+	}
+
+	c.last = time.Now()
+	c.mouse = mouse
+	return ""
+}
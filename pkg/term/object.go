@@ -0,0 +1,64 @@
+package term
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+// object contains the fields of the selected object, which can be used in the templates of the configuration file (e.g. '{{.Namespace}}/{{.Name}}').
+// The kind is 'Pod', 'Node' or 'Event'. The node is empty if it is not known and the container is only set in the pod details view.
+type object struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Node      string
+	Container string
+}
+
+// selectedObject returns the selected object of the focused view.
+// It returns false if no object is selected, e.g. because the table is empty or a group of events is selected.
+func (t *Term) selectedObject() (object, bool) {
+	view, _ := t.focused()
+	values := view.SelectedValues()
+
+	switch v := view.(type) {
+	case *widgets.NodesWidget:
+		if values != nil {
+			return object{Kind: "Node", Name: values[0], Node: values[0]}, true
+		}
+	case *widgets.PodsWidget:
+		if values != nil {
+			return object{Kind: "Pod", Namespace: values[0], Name: values[1], Node: values[11]}, true
+		}
+	case *widgets.EventsWidget:
+		if values != nil && !v.SelectedIsGroup() {
+			return object{Kind: "Event", Namespace: values[3], Name: values[4], Node: values[9]}, true
+		}
+	case *widgets.EventDetailsWidget:
+		if len(values) > 9 && values[4] != "" {
+			return object{Kind: "Event", Namespace: values[3], Name: values[4], Node: values[9]}, true
+		}
+	case *widgets.PodDetailsWidget:
+		name, namespace := v.Pod()
+		return object{Kind: "Pod", Namespace: namespace, Name: name, Container: v.SelectedContainer()}, true
+	}
+
+	return object{}, false
+}
+
+// executeTemplate renders the provided template with the fields of the object.
+func executeTemplate(text string, obj object) (string, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, obj); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,115 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// replayActionPrefix is the prefix of all actions, which control a replay. These actions can only be used when a recording is replayed.
+	replayActionPrefix = "replay"
+	// replaySeekStep is the duration by which a replay is moved backward and forward.
+	replaySeekStep = 30 * time.Second
+	// recordMaxAge is the maximum age of the data in the recording for the resources, which are not shown in the current view.
+	recordMaxAge = 30 * time.Second
+)
+
+// replayText returns the state of the replay, which is shown in the statusbar (e.g. 'Replay: Playing 2x 2006-01-02 15:04:05'). If no recording is replayed, an empty string is returned.
+func (t *Term) replayText() string {
+	if t.Replay == nil {
+		return ""
+	}
+
+	state := t.Replay.State()
+	mode := "Stopped"
+	if state.Playing {
+		mode = "Playing"
+	}
+
+	return fmt.Sprintf("Replay: %s %gx %s", mode, state.Speed, state.Time.Format("2006-01-02 15:04:05"))
+}
+
+// updateReplay updates the data of all visible widgets, after the clock of the replay was changed, so that the data for the new time is shown immediately.
+func (t *Term) updateReplay() {
+	t.view.Update()
+	t.updateSplit()
+	t.summary.Update()
+}
+
+// actionReplayPlay pauses or resumes the replay.
+func (t *Term) actionReplayPlay() {
+	t.Replay.TogglePlay()
+	t.updateReplay()
+}
+
+// actionReplaySlower halves the speed of the replay.
+func (t *Term) actionReplaySlower() {
+	t.Replay.SetSpeed(0.5)
+}
+
+// actionReplayFaster doubles the speed of the replay.
+func (t *Term) actionReplayFaster() {
+	t.Replay.SetSpeed(2)
+}
+
+// actionReplayBackward moves the replay back by the seek step.
+func (t *Term) actionReplayBackward() {
+	t.Replay.Seek(-replaySeekStep)
+	t.updateReplay()
+}
+
+// actionReplayForward moves the replay forward by the seek step.
+func (t *Term) actionReplayForward() {
+	t.Replay.Seek(replaySeekStep)
+	t.updateReplay()
+}
+
+// commandSeek moves the replay to the provided position.
+// The position can be a duration relative to the current time of the replay (e.g. '+5m' or '-30s'), a duration from the start of the recording (e.g. '10m') or a time of the day of the recording (e.g. '15:04:05').
+func commandSeek(t *Term, args []string) error {
+	if t.Replay == nil {
+		return fmt.Errorf("%v: only a replay can be seeked", ErrNotSupported)
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("%v: seek requires a position", ErrInvalidArgument)
+	}
+
+	position := args[0]
+	state := t.Replay.State()
+
+	if clock, err := time.ParseInLocation("15:04:05", position, state.Start.Location()); err == nil {
+		day := state.Start
+		target := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, day.Location())
+		if target.Before(state.Start) {
+			target = target.AddDate(0, 0, 1)
+		}
+
+		t.Replay.SeekTo(target)
+		t.updateReplay()
+		return nil
+	}
+
+	offset, err := time.ParseDuration(position)
+	if err != nil {
+		return fmt.Errorf("%v: %s", ErrInvalidArgument, position)
+	}
+
+	if strings.HasPrefix(position, "+") || strings.HasPrefix(position, "-") {
+		t.Replay.Seek(offset)
+	} else {
+		t.Replay.SeekTo(state.Start.Add(offset))
+	}
+
+	t.updateReplay()
+	return nil
+}
+
+// record writes the data, which was fetched by the last refresh, to the recording.
+// Errors are shown by reportErrors, so that a single failed write does not stop the recording.
+func (t *Term) record() {
+	if t.Recorder != nil {
+		t.Recorder.Record(recordMaxAge)
+	}
+}
@@ -0,0 +1,175 @@
+package term
+
+import (
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+const (
+	// defaultSplitRatio is the width of the left pane in percent of the terminal width, when the split layout is enabled.
+	defaultSplitRatio = 50
+	// minSplitRatio and maxSplitRatio are the limits for resizing the split layout.
+	minSplitRatio = 20
+	maxSplitRatio = 80
+	// splitRatioStep is the number of percent by which the split layout is resized with one key press.
+	splitRatioStep = 5
+)
+
+// split represents the optional split layout, where the table view is shown in the left pane and the details of the selected row in the right pane.
+// The details view is created again, when another row is selected. The list and the key are the view and the key of the row for which the details view was created.
+type split struct {
+	enabled   bool
+	focused   bool
+	ratio     int
+	list      widgets.View
+	key       string
+	view      widgets.View
+	viewType  widgets.ViewType
+	separator *ui.Block
+}
+
+// newSplit returns the split layout, which is disabled by default.
+func newSplit() *split {
+	separator := ui.NewBlock()
+	separator.Border = true
+	separator.BorderTop, separator.BorderBottom, separator.BorderRight = false, false, false
+
+	return &split{
+		enabled:   false,
+		focused:   false,
+		ratio:     defaultSplitRatio,
+		separator: separator,
+	}
+}
+
+// splitActive returns true if the split layout is enabled and can be used for the current view. Only the table views can be split.
+func (t *Term) splitActive() bool {
+	return t.split.enabled && viewTable(t.view) != nil
+}
+
+// focused returns the view of the focused pane. If the split layout is not active, this is always the current view.
+func (t *Term) focused() (widgets.View, widgets.ViewType) {
+	if t.splitActive() && t.split.focused && t.split.view != nil {
+		return t.split.view, t.split.viewType
+	}
+
+	return t.view, t.ViewType
+}
+
+// splitDetailsView returns the view for the right pane of the split layout.
+// For pods and events we show the details view, for nodes we show the pods running on the node. Groups of events do not have a details view, so nil is returned.
+func (t *Term) splitDetailsView(values []string) (widgets.View, widgets.ViewType) {
+	switch v := t.view.(type) {
+	case *widgets.NodesWidget:
+		filter := t.view.Filter()
+		filter.Node = values[0]
+		return t.newView(widgets.ViewTypePods, filter, t.defaultSortorder(widgets.ViewTypePods), t.termWidth, t.termHeight), widgets.ViewTypePods
+	case *widgets.PodsWidget:
+		return widgets.NewPodDetailsWidget(values[1], values[0], t.APIClient, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypePodDetails
+	case *widgets.EventsWidget:
+		if v.SelectedIsGroup() {
+			return nil, ""
+		}
+
+		return widgets.NewEventDetailsWidget(values[4], values[3], t.APIClient, t.view.Filter(), t.view.Sortorder(), t.termWidth, t.termHeight), widgets.ViewTypeEventDetails
+	}
+
+	return nil, ""
+}
+
+// syncSplit creates the details view for the right pane, when another row was selected in the left pane.
+// It returns true if a new details view was created, so that the caller can update the new view. All other updates are done in the refresh interval.
+func (t *Term) syncSplit() bool {
+	if !t.splitActive() {
+		t.split.list, t.split.key, t.split.view, t.split.focused = nil, "", nil, false
+		return false
+	}
+
+	table := viewTable(t.view)
+	key := table.RowKey(table.SelectedRow)
+	if t.view == t.split.list && key == t.split.key && t.split.view != nil {
+		return false
+	}
+
+	t.split.list, t.split.key = t.view, key
+	t.split.view, t.split.viewType = nil, ""
+	if values := t.view.SelectedValues(); values != nil {
+		t.split.view, t.split.viewType = t.splitDetailsView(values)
+	}
+
+	if t.split.view == nil {
+		t.split.focused = false
+		return false
+	}
+
+	t.resize(t.termWidth, t.termHeight)
+	return true
+}
+
+// layoutSplit sets the size of the left and the right pane below the provided top position.
+func (t *Term) layoutSplit(top int) {
+	left := t.termWidth * t.split.ratio / 100
+	t.view.SetRect(0, top, left, t.termHeight)
+	t.split.separator.SetRect(left, top, left+1, t.termHeight-1)
+	if t.split.view != nil {
+		t.split.view.SetRect(left+1, top, t.termWidth, t.termHeight)
+	}
+
+	t.split.separator.BorderStyle = ui.NewStyle(ui.ColorClear)
+	if t.split.focused {
+		t.split.separator.BorderStyle = ui.NewStyle(ui.ColorYellow)
+	}
+}
+
+// splitDrawables returns the widgets of the split layout, which must be rendered in addition to the current view.
+func (t *Term) splitDrawables() []ui.Drawable {
+	if !t.splitActive() || t.split.view == nil {
+		return nil
+	}
+
+	return []ui.Drawable{t.split.separator, t.split.view}
+}
+
+// actionSplit shows or hides the details of the selected row in the right pane.
+func (t *Term) actionSplit() {
+	t.split.enabled = !t.split.enabled
+	t.split.focused = false
+	if t.syncSplit() {
+		t.split.view.Update()
+	}
+	t.resize(t.termWidth, t.termHeight)
+}
+
+// actionSplitFocus switches the focus between the panes of the split layout.
+func (t *Term) actionSplitFocus() {
+	if t.splitActive() && t.split.view != nil {
+		t.split.focused = !t.split.focused
+		t.resize(t.termWidth, t.termHeight)
+	}
+}
+
+// actionSplitShrink decreases the width of the left pane.
+func (t *Term) actionSplitShrink() {
+	if t.split.ratio-splitRatioStep >= minSplitRatio {
+		t.split.ratio = t.split.ratio - splitRatioStep
+		t.resize(t.termWidth, t.termHeight)
+		t.updateSplit()
+	}
+}
+
+// actionSplitGrow increases the width of the left pane.
+func (t *Term) actionSplitGrow() {
+	if t.split.ratio+splitRatioStep <= maxSplitRatio {
+		t.split.ratio = t.split.ratio + splitRatioStep
+		t.resize(t.termWidth, t.termHeight)
+		t.updateSplit()
+	}
+}
+
+// updateSplit updates the details view in the right pane, e.g. to recalculate the layout of the details after the pane was resized.
+func (t *Term) updateSplit() {
+	if t.splitActive() && t.split.view != nil {
+		t.split.view.Update()
+	}
+}
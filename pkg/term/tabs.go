@@ -0,0 +1,124 @@
+package term
+
+import (
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/term/widgets"
+)
+
+// backgroundRefreshInterval is the interval in which the views of the tabs, which are not shown, are refreshed.
+// The interval must be shorter than the idle timeout of the tail mode of the events view, so that the watch of a tab in the background is not stopped.
+const backgroundRefreshInterval = 20 * time.Second
+
+// tab is an open tab with its own view and navigation history.
+// The view keeps the filter, the sortorder and the pause state of the tab. The state of the active tab is kept in the term and only saved to the tab, when another tab is selected.
+type tab struct {
+	view     widgets.View
+	viewType widgets.ViewType
+	history  []historyEntry
+	label    string
+	updated  time.Time
+}
+
+// saveTab saves the state of the active tab.
+func (t *Term) saveTab() {
+	active := t.tabs[t.activeTab]
+	active.view, active.viewType, active.history, active.label = t.view, t.ViewType, t.history, t.label
+}
+
+// selectTab saves the state of the active tab and restores the state of the tab with the provided index.
+func (t *Term) selectTab(index int) {
+	if index < 0 || index >= len(t.tabs) || index == t.activeTab {
+		return
+	}
+
+	t.saveTab()
+	t.restoreTab(index)
+}
+
+// restoreTab shows the tab with the provided index. Like for the views of the navigation history the current gauge mode is applied to the view of the tab.
+func (t *Term) restoreTab(index int) {
+	t.activeTab = index
+
+	selected := t.tabs[index]
+	setGaugeMode(selected.view, t.gaugeMode)
+	t.history, t.label = selected.history, selected.label
+	t.setView(selected.view, selected.viewType)
+	t.view.Update()
+}
+
+// tabLabels returns the labels of all tabs for the tab bar. The namespace filter is added to the label, so that tabs with the same view can be distinguished.
+func (t *Term) tabLabels() []string {
+	var labels []string
+	for index, tab := range t.tabs {
+		label, view := tab.label, tab.view
+		if index == t.activeTab {
+			label, view = t.label, t.view
+		}
+
+		if namespace := view.Filter().Namespace; namespace != "" {
+			label = label + " (" + namespace + ")"
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels
+}
+
+// fetchBackgroundTabs returns the functions to fetch the data of all tabs, which are not shown, in the background refresh interval.
+func (t *Term) fetchBackgroundTabs() []widgets.FetchFunc {
+	var fetches []widgets.FetchFunc
+	for index, tab := range t.tabs {
+		if index == t.activeTab || time.Since(tab.updated) < backgroundRefreshInterval {
+			continue
+		}
+
+		fetches = append(fetches, tab.view.Fetch())
+		tab.updated = time.Now()
+	}
+
+	return fetches
+}
+
+// actionNewTab opens a new tab with the default view and the filter from the configuration file.
+func (t *Term) actionNewTab() {
+	t.saveTab()
+
+	// The views of the previous tab are saved, so that we can not use switchView, which closes the current view.
+	viewType := t.defaultView()
+	view := t.newView(viewType, t.filter, t.defaultSortorder(viewType), t.termWidth, t.termHeight)
+	t.tabs = append(t.tabs, &tab{})
+	t.activeTab = len(t.tabs) - 1
+	t.history, t.label = nil, viewLabel(viewType, view.Filter())
+	t.setView(view, viewType)
+	t.view.Update()
+}
+
+// actionCloseTab closes the active tab and shows the previous tab. The last tab can not be closed.
+// The views of the closed tab are closed, so that e.g. the watch of the events view is stopped.
+func (t *Term) actionCloseTab() {
+	if len(t.tabs) < 2 {
+		return
+	}
+
+	t.closeViews()
+	closed := t.activeTab
+	t.tabs = append(t.tabs[:closed], t.tabs[closed+1:]...)
+
+	if closed > 0 {
+		t.restoreTab(closed - 1)
+	} else {
+		t.restoreTab(0)
+	}
+}
+
+// actionNextTab selects the next tab. After the last tab the first tab is selected.
+func (t *Term) actionNextTab() {
+	t.selectTab((t.activeTab + 1) % len(t.tabs))
+}
+
+// actionPreviousTab selects the previous tab. Before the first tab the last tab is selected.
+func (t *Term) actionPreviousTab() {
+	t.selectTab((t.activeTab - 1 + len(t.tabs)) % len(t.tabs))
+}
@@ -2,12 +2,18 @@ package term
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/ricoberger/kubetop/pkg/alerts"
 	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/config"
+	"github.com/ricoberger/kubetop/pkg/sink"
 	"github.com/ricoberger/kubetop/pkg/term/widgets"
 
 	ui "github.com/gizak/termui/v3"
@@ -16,18 +22,304 @@ import (
 // Term represents the user interface for kubetop.
 // To initialize the view we need an API client for the interaction with the Kubernetes API.
 // We also need a view type to know which view/widget should be rendered.
+// The tail retention is the maximum number of events, which are shown in the tail mode of the events view.
+// The alerts engine is optional and evaluates the alert rules against the pods and nodes views.
+// The gauge thresholds are used to color the cpu and memory gauges in the pods and nodes views.
+// The config contains the settings from the configuration file, like the columns of the table views, the default sortorders, the keybindings and the theme.
+// If the view type is empty, the default view from the configuration file is rendered.
+// The sink is optional and is only used to show the errors of the event sink, the events are sent to the sink by the API client.
+// The recorder is optional and writes the data of the cluster to a recording. When a recording is replayed, the replay is also the API client of the term and is used to control the replay.
 type Term struct {
-	APIClient *api.Client
-	ViewType  widgets.ViewType
+	APIClient       api.Source
+	Alerts          *alerts.Engine
+	Config          *config.Config
+	ViewType        widgets.ViewType
+	TailRetention   int
+	GaugeThresholds widgets.Thresholds
+	Sink            *sink.Sink
+	Recorder        *api.Recorder
+	Replay          *api.Replay
+
+	columns    map[widgets.ViewType][]widgets.Column
+	gaugeMode  widgets.GaugeMode
+	keys       keymap
+	view       widgets.View
+	filter     api.Filter
+	tabs       []*tab
+	activeTab  int
+	tabbar     *widgets.TabBarWidget
+	history    []historyEntry
+	split      *split
+	label      string
+	query      string
+	mutex      sync.Mutex
+	suspend    sync.Mutex
+	breadcrumb *widgets.BreadcrumbWidget
+	statusbar  *widgets.StatusbarWidget
+	summary    *widgets.SummaryWidget
+	list       *widgets.ListWidget
+	picker     *widgets.ColumnPickerWidget
+	help       *widgets.HelpWidget
+	prompt     *widgets.PromptWidget
+	listActive bool
+	listType   widgets.ListType
+	termWidth  int
+	termHeight int
+	quit       bool
 }
 
 var (
 	// ErrInitializeView is thrown if the view could not initialized.
 	ErrInitializeView = errors.New("could not initialize view")
+	// ErrUnknownTheme is thrown if the theme from the configuration file is neither a custom theme nor a built-in theme.
+	ErrUnknownTheme = errors.New("unknown theme")
 )
 
+// defaultSortorder returns the sortorder which is used when a view is opened.
+// The sortorder from the configuration file is preferred. Otherwise events are sorted by their age, so that the newest events are shown first, and pods are sorted by their namespace and then by their name.
+func (t *Term) defaultSortorder(viewType widgets.ViewType) api.Sort {
+	if t.Config != nil {
+		if sortorder, ok := t.Config.Defaults.Sort[configKeys[viewType]]; ok && sortorder.Primary.Column != "" {
+			return sortorder
+		}
+	}
+
+	switch viewType {
+	case widgets.ViewTypeNodes:
+		return api.NewSort("name", false)
+	case widgets.ViewTypeEvents:
+		return api.NewSort("age", false)
+	default:
+		return api.Sort{Primary: api.SortKey{Column: "namespace"}, Secondary: api.SortKey{Column: "name"}}
+	}
+}
+
+// newView creates the widget for the nodes, pods or events view.
+// For all other view types nil is returned, because these views need additional information like the name of the selected pod.
+// The table views are rendered below the cluster summary, so we move them down by the height of the summary.
+func (t *Term) newView(viewType widgets.ViewType, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) widgets.View {
+	var view widgets.View
+
+	switch viewType {
+	case widgets.ViewTypeNodes:
+		nodes := widgets.NewNodesWidget(t.APIClient, t.Alerts, filter, sortorder, termWidth, termHeight)
+		nodes.GaugeMode, nodes.GaugeThresholds = t.gaugeMode, t.GaugeThresholds
+		t.setColumns(viewType, nodes.Table)
+		view = nodes
+	case widgets.ViewTypePods:
+		pods := widgets.NewPodsWidget(t.APIClient, t.Alerts, filter, sortorder, termWidth, termHeight)
+		pods.GaugeMode, pods.GaugeThresholds = t.gaugeMode, t.GaugeThresholds
+		t.setColumns(viewType, pods.Table)
+		view = pods
+	case widgets.ViewTypeEvents:
+		events := widgets.NewEventsWidget(t.APIClient, filter, sortorder, t.TailRetention, termWidth, termHeight)
+		t.setColumns(viewType, events.Table)
+		view = events
+	default:
+		return nil
+	}
+
+	view.SetRect(0, widgets.SummaryHeight, termWidth, termHeight)
+	return view
+}
+
+// configKeys maps the view types to the keys, which are used for the per view settings in the configuration file.
+var configKeys = map[widgets.ViewType]string{
+	widgets.ViewTypeNodes:  "nodes",
+	widgets.ViewTypePods:   "pods",
+	widgets.ViewTypeEvents: "events",
+}
+
+// setColumns applies the column model for the view type to the table.
+// Changes which were done via the column picker are preferred over the columns from the configuration file, so that they are kept when the view is changed.
+func (t *Term) setColumns(viewType widgets.ViewType, table *widgets.Table) {
+	if columns, ok := t.columns[viewType]; ok {
+		table.Columns = widgets.CopyColumns(columns)
+		return
+	}
+
+	if t.Config != nil {
+		table.ConfigureColumns(t.Config.Columns[configKeys[viewType]])
+	}
+}
+
+// validateColumns checks that the columns from the configuration file contain at least one column of the view, so that the table of the view does not only show the header bar.
+func (t *Term) validateColumns() error {
+	if t.Config == nil {
+		return nil
+	}
+
+	for viewType, key := range configKeys {
+		if err := widgets.ValidateColumns(widgets.ViewColumns(viewType, t.APIClient), t.Config.Columns[key]); err != nil {
+			return fmt.Errorf("%v for the %s view", err, key)
+		}
+	}
+
+	return nil
+}
+
+// viewTable returns the table of the nodes, pods and events view.
+// For all other views nil is returned, because they do not have a column model.
+func viewTable(view widgets.View) *widgets.Table {
+	switch v := view.(type) {
+	case *widgets.NodesWidget:
+		return v.Table
+	case *widgets.PodsWidget:
+		return v.Table
+	case *widgets.EventsWidget:
+		return v.Table
+	}
+
+	return nil
+}
+
+// defaultView returns the view type of the default view from the configuration file.
+// If no default view is configured, we render the pods view.
+func (t *Term) defaultView() widgets.ViewType {
+	if t.Config != nil {
+		for viewType, key := range configKeys {
+			if key == t.Config.Defaults.View {
+				return viewType
+			}
+		}
+	}
+
+	return widgets.ViewTypePods
+}
+
+// theme returns the theme for the user interface.
+// The theme is selected by its name from the themes of the configuration file or from the built-in themes. Colors which are not set in a custom theme are taken from the default theme.
+func (t *Term) theme() (widgets.Theme, error) {
+	if t.Config == nil || t.Config.Theme == "" {
+		return widgets.Themes["default"], nil
+	}
+
+	custom, ok := t.Config.Themes[t.Config.Theme]
+	if !ok {
+		theme, ok := widgets.Themes[t.Config.Theme]
+		if !ok {
+			return theme, fmt.Errorf("%v: %s", ErrUnknownTheme, t.Config.Theme)
+		}
+
+		return theme, nil
+	}
+
+	theme := widgets.Themes["default"]
+	for _, style := range []struct {
+		config config.Style
+		style  *ui.Style
+	}{
+		{custom.Header, &theme.Header},
+		{custom.Cursor, &theme.Cursor},
+		{custom.Statusbar, &theme.Statusbar},
+		{custom.List, &theme.List},
+		{custom.Marked, &theme.Marked},
+	} {
+		if style.config.Fg != "" {
+			fg, err := widgets.ParseColor(style.config.Fg)
+			if err != nil {
+				return theme, err
+			}
+			style.style.Fg = fg
+		}
+
+		if style.config.Bg != "" {
+			bg, err := widgets.ParseColor(style.config.Bg)
+			if err != nil {
+				return theme, err
+			}
+			style.style.Bg = bg
+		}
+	}
+
+	return theme, nil
+}
+
+// adjacentSortColumn returns the visible column before (step -1) or after (step 1) the column by which the table is sorted.
+// When the sorted column is not visible, we start with the first visible column.
+func adjacentSortColumn(table *widgets.Table, column string, step int) string {
+	columns := table.VisibleColumns()
+	if len(columns) == 0 {
+		return column
+	}
+
+	for index, name := range columns {
+		if name == column {
+			return columns[(index+step+len(columns))%len(columns)]
+		}
+	}
+
+	return columns[0]
+}
+
+// involvedObjectView returns the view for the involved object of the selected event.
+// The values are the values of the selected event as they are returned by the events and event details widget.
+// Pods are opened in the pod details view, for nodes we show all pods running on the node and for workloads we show all pods selected by the workload.
+// The label of the view is used for the breadcrumbs. If we could not find a view for the involved object nil is returned.
+func (t *Term) involvedObjectView(values []string, termWidth, termHeight int) (widgets.View, widgets.ViewType, string) {
+	if len(values) < 13 || values[11] == "" {
+		return nil, t.ViewType, ""
+	}
+
+	kind, namespace, name := values[6], values[10], values[11]
+	filter := api.Filter{Namespace: "", Node: "", Status: 10}
+
+	switch kind {
+	case "Pod":
+		return widgets.NewPodDetailsWidget(name, namespace, t.APIClient, api.Filter{Namespace: namespace, Node: "", Status: 10}, t.defaultSortorder(widgets.ViewTypePods), termWidth, termHeight), widgets.ViewTypePodDetails, "Pod " + namespace + "/" + name
+	case "Node":
+		filter.Node = name
+		return t.newView(widgets.ViewTypePods, filter, t.defaultSortorder(widgets.ViewTypePods), termWidth, termHeight), widgets.ViewTypePods, viewLabel(widgets.ViewTypePods, filter)
+	default:
+		selector, err := t.APIClient.GetWorkloadSelector(kind, namespace, name)
+		if err != nil {
+			return nil, t.ViewType, ""
+		}
+
+		filter.Namespace = namespace
+		filter.Selector = selector
+		return t.newView(widgets.ViewTypePods, filter, t.defaultSortorder(widgets.ViewTypePods), termWidth, termHeight), widgets.ViewTypePods, "Pods of " + kind + " " + namespace + "/" + name
+	}
+}
+
 // Run initialize the user interface and handles the core logic for user interactions.
+// All key events are dispatched through the keybinding registry, only the column picker, the help and the prompt handle their keys on their own.
 func (t *Term) Run(filter api.Filter) error {
+	// Load the keybindings, the theme and the refresh interval from the configuration file.
+	// We do this before termui is initialized, so that errors in the configuration are not hidden by the user interface.
+	var keybindings map[string][]string
+	refreshInterval := 2 * time.Second
+	if t.Config != nil {
+		keybindings = t.Config.Keybindings
+
+		interval, err := t.Config.RefreshInterval()
+		if err != nil {
+			return err
+		}
+		refreshInterval = interval
+	}
+
+	keys, err := newKeymap(keybindings)
+	if err != nil {
+		return err
+	}
+	keys.addHooks(t.hooks())
+	t.keys = keys
+
+	theme, err := t.theme()
+	if err != nil {
+		return err
+	}
+	widgets.SetTheme(theme)
+
+	if err := t.validateColumns(); err != nil {
+		return err
+	}
+
+	if t.ViewType == "" {
+		t.ViewType = t.defaultView()
+	}
+
 	// Initialize termui.
 	if err := ui.Init(); err != nil {
 		return err
@@ -35,47 +327,66 @@ func (t *Term) Run(filter api.Filter) error {
 	defer ui.Close()
 
 	// Create the view for kubetop.
-	// The listActive var to change the behaviour of the key events when the list is shown.
 	// We Check ViewType of the term to know which view should be rendered.
 	// Then we create the corresponding widget and pass the needed data to this widget (e.g. the width and height of the terminal).
-	var listActive bool
-	var listType widgets.ListType = widgets.ListTypeSort
-	var view widgets.View
-	var sortorder api.Sort
-	termWidth, termHeight := ui.TerminalDimensions()
-
-	if t.ViewType == widgets.ViewTypeNodes {
-		sortorder = api.SortName
-		view = widgets.NewNodesWidget(t.APIClient, filter, sortorder, termWidth, termHeight)
-	} else if t.ViewType == widgets.ViewTypePods {
-		sortorder = api.SortNamespace
-		view = widgets.NewPodsWidget(t.APIClient, filter, sortorder, termWidth, termHeight)
-	} else if t.ViewType == widgets.ViewTypeEvents {
-		sortorder = api.SortTimeDESC
-		view = widgets.NewEventsWidget(t.APIClient, filter, sortorder, termWidth, termHeight)
-	}
+	t.termWidth, t.termHeight = ui.TerminalDimensions()
+	t.gaugeMode = widgets.GaugeModeBars
+	t.columns = make(map[widgets.ViewType][]widgets.Column)
+	t.listType = widgets.ListTypeSort
 
-	if view == nil {
+	sortorder := t.defaultSortorder(t.ViewType)
+	t.view = t.newView(t.ViewType, filter, sortorder, t.termWidth, t.termHeight)
+	if t.view == nil {
 		return ErrInitializeView
 	}
 
-	statusbar := widgets.NewStatusbarWidget(t.APIClient, filter, view.Pause(), sortorder, t.ViewType, termWidth, termHeight)
-	list := widgets.NewListWidget(t.APIClient)
+	t.statusbar = widgets.NewStatusbarWidget(t.APIClient, filter, t.view.Pause(), sortorder, t.ViewType, t.termWidth, t.termHeight)
+	t.summary = widgets.NewSummaryWidget(t.APIClient, t.ViewType, t.termWidth, t.termHeight)
+	t.list = widgets.NewListWidget(t.APIClient, t.Alerts)
+	t.picker = widgets.NewColumnPickerWidget()
+	t.help = widgets.NewHelpWidget()
+	t.prompt = widgets.NewPromptWidget()
+	t.breadcrumb = widgets.NewBreadcrumbWidget()
+	t.split = newSplit()
+	t.tabbar = widgets.NewTabBarWidget()
+	t.filter = filter
+	t.tabs = []*tab{{}}
+	t.label = viewLabel(t.ViewType, filter)
 
-	// Create a goroutine for our view to refresh the data every two seconds.
+	// Create a goroutine for our view to refresh the data in the configured interval (by default every two seconds).
+	// The cluster summary is refreshed together with the view, so that it can use the data fetched by the view.
+	// The view, the tabs and the widgets are also changed by the key events, so that we have to hold the mutex while the fetched data is shown and rendered.
+	// The data is fetched without holding the mutex, so that the key events are handled while the requests against the Kubernetes API are running.
 	go func() {
 		for {
-			view.Update()
-			ui.Clear()
-			ui.Render(view, statusbar, list)
-			time.Sleep(2 * time.Second)
+			t.mutex.Lock()
+			fetches := t.fetches()
+			t.mutex.Unlock()
+
+			var applies []func()
+			for _, fetch := range fetches {
+				if apply, err := fetch(); err == nil {
+					applies = append(applies, apply)
+				}
+			}
+			t.record()
+
+			t.mutex.Lock()
+			for _, apply := range applies {
+				apply()
+			}
+			t.reportErrors()
+			t.render()
+			t.mutex.Unlock()
+			time.Sleep(refreshInterval)
 		}
 	}()
 
 	// Render our view and get all key events from the user.
-	ui.Render(view, statusbar, list)
+	t.render()
 	uiEvents := ui.PollEvents()
 	previousKey := ""
+	mouseClicks := &clicks{}
 
 	// Handle kill signal sent event.
 	sigTerm := make(chan os.Signal, 2)
@@ -89,215 +400,147 @@ func (t *Term) Run(filter api.Filter) error {
 		case <-sigTerm:
 			return nil
 		case e := <-uiEvents:
-			switch e.ID {
-			case "q", "<C-c>":
-				return nil
-			case "<Resize>":
-				payload := e.Payload.(ui.Resize)
-				termWidth, termHeight = payload.Width, payload.Height
-				view.SetRect(0, 0, termWidth, termHeight)
-				statusbar.SetRect(0, termHeight-1, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "k", "<Up>", "<MouseWheelUp>":
-				if listActive {
-					list.ScrollUp()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				} else {
-					view.SelectPrev()
+			t.mutex.Lock()
 
-					// If we are in the pod details view we need to update the view, because we need to load the logs for the selected container.
-					if t.ViewType == widgets.ViewTypePodDetails {
-						view.Update()
-					}
-
-					ui.Clear()
-					ui.Render(view, statusbar, list)
+			switch {
+			case e.ID == "<C-c>":
+				t.quit = true
+			case e.ID == "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				t.resize(payload.Width, payload.Height)
+			case t.picker.Active():
+				t.handlePicker(e.ID)
+			case t.prompt.Active():
+				t.handlePrompt(e.ID)
+			case t.help.Active():
+				// When the help is shown, it can be scrolled. All other keys close the help.
+				switch e.ID {
+				case "k", "<Up>", "<MouseWheelUp>":
+					t.help.ScrollUp()
+				case "j", "<Down>", "<MouseWheelDown>":
+					t.help.ScrollDown()
+				default:
+					t.help.Hide()
 				}
-			case "j", "<Down>", "<MouseWheelDown>":
-				if listActive {
-					list.ScrollDown()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
+			case e.ID == "<MouseLeft>":
+				// Clicks on the list, the statusbar and double clicks on a row are translated to the actions of the keybinding registry.
+				// All other clicks are handled by the view.
+				mouse := e.Payload.(ui.Mouse)
+				if action := mouseClicks.action(mouse, t.view, t.statusbar, t.list, t.listActive); action != "" {
+					t.dispatch(action)
 				} else {
-					view.SelectNext()
-
-					// If we are in the pod details view we need to update the view, because we need to load the logs for the selected container.
-					if t.ViewType == widgets.ViewTypePodDetails {
-						view.Update()
-					}
-
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "<Home>":
-				if !listActive {
-					view.SelectTop()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "g":
-				if !listActive {
-					if previousKey == "g" {
-						view.SelectTop()
-						ui.Clear()
-						ui.Render(view, statusbar, list)
-					}
-				}
-			case "G", "<End>":
-				if !listActive {
-					view.SelectBottom()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "<C-d>":
-				if !listActive {
-					view.SelectHalfPageDown()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "<C-u>":
-				if !listActive {
-					view.SelectHalfPageUp()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "<C-f>":
-				if !listActive {
-					view.SelectPageDown()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
+					t.click(mouse)
 				}
-			case "<C-b>":
-				if !listActive {
-					view.SelectPageUp()
-					ui.Clear()
-					ui.Render(view, statusbar, list)
-				}
-			case "p":
-				view.TogglePause()
-				statusbar.SetPause(view.Pause())
-				view.Update()
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<Enter>":
-				if listActive {
-					viewType, sortorder, filter := list.Selected(t.ViewType, listType, view.Sortorder(), view.Filter())
-					if viewType != t.ViewType {
-						t.ViewType = viewType
-						filter := api.Filter{Namespace: "", Node: "", Status: 10}
-
-						if viewType == widgets.ViewTypeNodes {
-							view = widgets.NewNodesWidget(t.APIClient, filter, api.SortName, termWidth, termHeight)
-							statusbar.SetViewType(t.ViewType)
-							statusbar.SetSortAndFilter(api.SortName, filter)
-							statusbar.SetPause(false)
-						} else if viewType == widgets.ViewTypePods {
-							view = widgets.NewPodsWidget(t.APIClient, filter, api.SortNamespace, termWidth, termHeight)
-							statusbar.SetViewType(t.ViewType)
-							statusbar.SetSortAndFilter(api.SortNamespace, filter)
-							statusbar.SetPause(false)
-						} else if viewType == widgets.ViewTypeEvents {
-							view = widgets.NewEventsWidget(t.APIClient, filter, api.SortTimeDESC, termWidth, termHeight)
-							statusbar.SetViewType(t.ViewType)
-							statusbar.SetSortAndFilter(api.SortTimeDESC, filter)
-							statusbar.SetPause(false)
-						}
-					} else {
-						view.SetSortAndFilter(sortorder, filter)
-						statusbar.SetSortAndFilter(sortorder, filter)
-					}
-
-					listActive = false
+			case e.ID == "<MouseWheelUp>":
+				t.dispatch("up")
+			case e.ID == "<MouseWheelDown>":
+				t.dispatch("down")
+			default:
+				// Two keys pressed after each other (e.g. 'gg') can also be bound to an action.
+				// The key is remembered until a combination of two keys was dispatched, so that a key which does not complete a combination can start the next one.
+				if action, ok := t.keys.action(previousKey + e.ID); ok && previousKey != "" {
+					t.dispatch(action)
+					previousKey = ""
 				} else {
-					if t.ViewType == widgets.ViewTypeNodes {
-						selectedRow := view.SelectedValues()
-						nodeFilter := view.Filter()
-						nodeFilter.Node = selectedRow[0]
-
-						view = widgets.NewPodsWidget(t.APIClient, nodeFilter, api.SortNamespace, termWidth, termHeight)
-						t.ViewType = widgets.ViewTypePods
-						statusbar.SetViewType(t.ViewType)
-						statusbar.SetPause(false)
-					} else if t.ViewType == widgets.ViewTypePods {
-						selectedRow := view.SelectedValues()
-
-						view = widgets.NewPodDetailsWidget(selectedRow[1], selectedRow[0], t.APIClient, view.Filter(), view.Sortorder(), termWidth, termHeight)
-						t.ViewType = widgets.ViewTypePodDetails
-						statusbar.SetViewType(t.ViewType)
-						statusbar.SetPause(false)
-					} else if t.ViewType == widgets.ViewTypeEvents {
-						selectedRow := view.SelectedValues()
-
-						view = widgets.NewEventDetailsWidget(selectedRow[5], selectedRow[4], t.APIClient, view.Filter(), view.Sortorder(), termWidth, termHeight)
-						t.ViewType = widgets.ViewTypeEventDetails
-						statusbar.SetViewType(t.ViewType)
-						statusbar.SetPause(false)
+					if action, ok := t.keys.action(e.ID); ok {
+						t.dispatch(action)
 					}
+					previousKey = e.ID
 				}
+			}
 
-				view.Update()
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<Escape>":
-				if listActive {
-					list.Hide()
-					listActive = false
-				}
+			if t.quit {
+				t.mutex.Unlock()
+				return nil
+			}
 
-				if t.ViewType == widgets.ViewTypePodDetails {
-					view = widgets.NewPodsWidget(t.APIClient, view.Filter(), view.Sortorder(), termWidth, termHeight)
-					t.ViewType = widgets.ViewTypePods
-					statusbar.SetViewType(t.ViewType)
-					statusbar.SetPause(false)
-				} else if t.ViewType == widgets.ViewTypeEventDetails {
-					view = widgets.NewEventsWidget(t.APIClient, view.Filter(), view.Sortorder(), termWidth, termHeight)
-					t.ViewType = widgets.ViewTypeEvents
-					statusbar.SetViewType(t.ViewType)
-					statusbar.SetPause(false)
-				}
+			if t.syncSplit() {
+				t.split.view.Update()
+			}
+			t.render()
+			t.mutex.Unlock()
+		}
+	}
+}
 
-				view.Update()
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<F1>":
-				listType = widgets.ListTypeSort
-				listActive = list.Show(t.ViewType, listType, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<F2>":
-				listType = widgets.ListTypeFilterNamespace
-				listActive = list.Show(t.ViewType, listType, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<F3>":
-				listType = widgets.ListTypeFilterNode
-				listActive = list.Show(t.ViewType, listType, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "<F4>":
-				if t.ViewType == widgets.ViewTypePods {
-					listType = widgets.ListTypeFilterStatus
-				} else if t.ViewType == widgets.ViewTypeEvents {
-					listType = widgets.ListTypeFilterEventType
-				}
+// fetches returns the functions to fetch the data of the current view, the details view of the split layout, the cluster summary and the views of the tabs in the background.
+// The functions must be created while the mutex is held, because they use the settings of the views.
+func (t *Term) fetches() []widgets.FetchFunc {
+	fetches := []widgets.FetchFunc{t.view.Fetch()}
 
-				listActive = list.Show(t.ViewType, listType, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			case "v":
-				listType = widgets.ListTypeView
-				listActive = list.Show(t.ViewType, listType, termWidth, termHeight)
-				ui.Clear()
-				ui.Render(view, statusbar, list)
-			}
+	t.syncSplit()
+	if t.splitActive() && t.split.view != nil {
+		fetches = append(fetches, t.split.view.Fetch())
+	}
 
-			if previousKey == e.ID {
-				previousKey = ""
-			} else {
-				previousKey = e.ID
-			}
+	fetches = append(fetches, t.summary.Fetch())
+	return append(fetches, t.fetchBackgroundTabs()...)
+}
+
+// handlePrompt handles the key events while the prompt is shown.
+// The command or the search is executed with '<Enter>'. If the command fails, the prompt stays open and shows the error, so that the command can be corrected.
+func (t *Term) handlePrompt(key string) {
+	switch key {
+	case "<Escape>":
+		t.prompt.Hide()
+	case "<Enter>":
+		var err error
+		if t.prompt.Prefix() == "/" {
+			t.query = t.prompt.Input()
+			err = t.search(1)
+		} else {
+			err = t.execute(t.prompt.Input())
+		}
+
+		if err != nil {
+			t.prompt.SetMessage(err.Error(), true)
+		} else {
+			t.prompt.Hide()
+		}
+	case "<Tab>":
+		if t.prompt.Prefix() == "/" {
+			return
+		}
+
+		input, candidates := t.complete(t.prompt.Input())
+		t.prompt.SetInput(input)
+		if len(candidates) > 1 {
+			t.prompt.SetMessage(strings.Join(candidates, " "), false)
+		}
+	case "<Backspace>", "<C-<Backspace>>":
+		t.prompt.Backspace()
+	case "<Space>":
+		t.prompt.Insert(" ")
+	default:
+		// All other keys with a single character are added to the input, special keys like '<F1>' are ignored.
+		if len([]rune(key)) == 1 {
+			t.prompt.Insert(key)
+		}
+	}
+}
+
+// handlePicker handles the key events while the column picker is shown.
+// The changed columns are saved when the picker is closed, so that they are used for new views of the same type.
+func (t *Term) handlePicker(key string) {
+	switch key {
+	case "k", "<Up>":
+		t.picker.ScrollUp()
+	case "j", "<Down>":
+		t.picker.ScrollDown()
+	case "<Space>", "<Enter>":
+		t.picker.Toggle()
+	case "K":
+		t.picker.Move(-1)
+	case "J":
+		t.picker.Move(1)
+	case "+":
+		t.picker.Resize(5)
+	case "-":
+		t.picker.Resize(-5)
+	case "c", "<Escape>":
+		if table := viewTable(t.view); table != nil {
+			t.columns[t.ViewType] = widgets.CopyColumns(table.Columns)
 		}
+		t.picker.Hide()
 	}
 }
@@ -0,0 +1,30 @@
+package widgets
+
+import (
+	"github.com/ricoberger/kubetop/pkg/alerts"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// alertColors returns the row colors for all rows with a firing alert.
+// The keys of the rows must be the keys, which are used by the alerts engine (e.g. '<namespace>/<name>' for pods).
+// The color of a row depends on the highest severity of all firing alerts for the object.
+func alertColors(severities map[string]alerts.Severity, keys []string) map[int]ui.Color {
+	if len(severities) == 0 {
+		return nil
+	}
+
+	colors := make(map[int]ui.Color)
+	for index, key := range keys {
+		switch severities[key] {
+		case alerts.SeverityInfo:
+			colors[index] = ui.ColorBlue
+		case alerts.SeverityWarning:
+			colors[index] = ui.ColorYellow
+		case alerts.SeverityCritical:
+			colors[index] = ui.ColorRed
+		}
+	}
+
+	return colors
+}
@@ -0,0 +1,67 @@
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// BreadcrumbHeight is the number of lines which are used by the breadcrumb widget above the view.
+const BreadcrumbHeight = 1
+
+// breadcrumbSeparator is rendered between the entries of the path.
+const breadcrumbSeparator = " > "
+
+// BreadcrumbWidget represents the line above the view, which shows the path of the views which were opened to get to the current view (e.g. 'Nodes > Pods on node-1 > Pod default/nginx').
+type BreadcrumbWidget struct {
+	*ui.Block
+
+	path []string
+}
+
+// NewBreadcrumbWidget returns a new breadcrumb widget.
+func NewBreadcrumbWidget() *BreadcrumbWidget {
+	block := ui.NewBlock()
+	block.Border = false
+
+	return &BreadcrumbWidget{
+		block,
+
+		nil,
+	}
+}
+
+// SetPath sets the labels of all views in the navigation history. The last label is the label of the current view.
+func (b *BreadcrumbWidget) SetPath(path []string) {
+	b.path = path
+}
+
+// Visible returns true if the breadcrumbs are rendered. We only render the breadcrumbs, when there is a view to go back to.
+func (b *BreadcrumbWidget) Visible() bool {
+	return len(b.path) > 1
+}
+
+// Draw renders the breadcrumbs. The current view is highlighted and if the terminal is to small we cut of the beginning of the path.
+func (b *BreadcrumbWidget) Draw(buf *ui.Buffer) {
+	if !b.Visible() {
+		return
+	}
+
+	y := b.Inner.Min.Y + (b.Inner.Dy() / 2)
+	parents := strings.Join(b.path[:len(b.path)-1], breadcrumbSeparator) + breadcrumbSeparator
+	current := b.path[len(b.path)-1]
+
+	parentsWidth := len([]rune(parents))
+	if overflow := parentsWidth + len([]rune(current)) - b.Inner.Dx(); overflow > 0 {
+		if overflow < parentsWidth {
+			parents = string(ui.ELLIPSES) + string([]rune(parents)[overflow+1:])
+		} else {
+			parents = ""
+		}
+		parentsWidth = len([]rune(parents))
+	}
+
+	buf.SetString(parents, ui.NewStyle(ui.ColorClear), image.Pt(b.Inner.Min.X, y))
+	buf.SetString(ui.TrimString(current, b.Inner.Dx()-parentsWidth), ui.NewStyle(ui.ColorClear, ui.ColorClear, ui.ModifierBold), image.Pt(b.Inner.Min.X+parentsWidth, y))
+}
@@ -0,0 +1,93 @@
+package widgets
+
+import (
+	"fmt"
+
+	w "github.com/gizak/termui/v3/widgets"
+)
+
+// ColumnPickerWidget represents the modal to show, hide, order and resize the columns of a table view.
+// All changes are applied directly to the column model of the table, so that the user can see the result immediately.
+type ColumnPickerWidget struct {
+	*w.List
+
+	active bool
+	table  *Table
+}
+
+// NewColumnPickerWidget returns a new column picker widget.
+func NewColumnPickerWidget() *ColumnPickerWidget {
+	list := w.NewList()
+	list.Title = "Columns ... ([Space] Toggle, [J/K] Move, [+/-] Width)"
+	list.TextStyle = theme.List
+	list.WrapText = false
+
+	return &ColumnPickerWidget{
+		list,
+
+		false,
+		nil,
+	}
+}
+
+// Active returns if the column picker is shown.
+func (c *ColumnPickerWidget) Active() bool {
+	return c.active
+}
+
+// Show shows the column picker for the provided table.
+// If the table does not have a column model, the column picker is not shown and false is returned.
+func (c *ColumnPickerWidget) Show(table *Table, termWidth, termHeight int) bool {
+	if table == nil || table.Columns == nil {
+		return false
+	}
+
+	c.active = true
+	c.table = table
+	c.SelectedRow = 0
+	c.render()
+	c.SetRect(termWidth/2-30, termHeight/2-10, termWidth/2+30, termHeight/2+10)
+
+	return true
+}
+
+// Hide hides the column picker.
+func (c *ColumnPickerWidget) Hide() {
+	c.active = false
+	c.table = nil
+	c.SetRect(0, 0, 0, 0)
+}
+
+// Toggle shows or hides the selected column.
+func (c *ColumnPickerWidget) Toggle() {
+	c.table.ToggleColumn(c.SelectedRow)
+	c.render()
+}
+
+// Move moves the selected column up (negative offset) or down (positive offset).
+// The selection follows the moved column.
+func (c *ColumnPickerWidget) Move(offset int) {
+	c.SelectedRow = c.table.MoveColumn(c.SelectedRow, offset)
+	c.render()
+}
+
+// Resize changes the width of the selected column.
+func (c *ColumnPickerWidget) Resize(offset int) {
+	c.table.ResizeColumn(c.SelectedRow, offset)
+	c.render()
+}
+
+// render renders one row for each column of the table.
+// Visible columns are marked with an 'x', the number is the preferred width of the column.
+func (c *ColumnPickerWidget) render() {
+	c.Rows = []string{}
+
+	for _, column := range c.table.Columns {
+		visible := " "
+		if column.Visible {
+			visible = "x"
+		}
+
+		c.Rows = append(c.Rows, fmt.Sprintf("[%s] %-20s %3d", visible, column.Header, column.Width))
+	}
+}
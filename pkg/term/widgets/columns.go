@@ -0,0 +1,332 @@
+package widgets
+
+import (
+	"errors"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/config"
+)
+
+var (
+	// ErrNoVisibleColumns is thrown if columns are configured for a view, but none of them is a column of the view, so that the table would not show any column.
+	ErrNoVisibleColumns = errors.New("no visible columns")
+)
+
+const (
+	// minColumnWidth is the minimum width of a column, when the columns are shrunk to fit into the terminal.
+	minColumnWidth = 6
+	// defaultCustomColumnWidth is the width of a custom column, when no width is configured.
+	defaultCustomColumnWidth = 20
+)
+
+// Column represents a column of a table view.
+// The index is the position of the value for the column in the rows of the table. The width is the preferred width of the column.
+// Flexible columns are getting the space which is not used by the other columns.
+type Column struct {
+	Name    string
+	Header  string
+	Index   int
+	Width   int
+	Flex    bool
+	Visible bool
+}
+
+// CopyColumns returns a copy of the provided columns, so that a changed column model is not shared between views.
+func CopyColumns(columns []Column) []Column {
+	if columns == nil {
+		return nil
+	}
+
+	return append([]Column(nil), columns...)
+}
+
+// ViewColumns returns all columns of the nodes, pods or events view including the custom columns. For all other views nil is returned.
+func ViewColumns(viewType ViewType, apiClient api.Source) []Column {
+	switch viewType {
+	case ViewTypeNodes:
+		return append(CopyColumns(NodeColumns), customColumns(apiClient.NodeCustomColumns(), len(NodeColumns))...)
+	case ViewTypePods:
+		return append(CopyColumns(PodColumns), customColumns(apiClient.PodCustomColumns(), len(PodColumns))...)
+	case ViewTypeEvents:
+		return CopyColumns(EventColumns)
+	}
+
+	return nil
+}
+
+// ValidateColumns returns an error if columns are configured, but none of them is one of the provided columns.
+func ValidateColumns(columns []Column, configured []config.Column) error {
+	if len(configured) == 0 {
+		return nil
+	}
+
+	for _, c := range configured {
+		for _, column := range columns {
+			if column.Name == c.Name {
+				return nil
+			}
+		}
+	}
+
+	return ErrNoVisibleColumns
+}
+
+// customColumns returns the columns for the custom columns of the pods or nodes view.
+// The values of the custom columns are added after the values of the built-in columns, so the index of the first custom column is the number of built-in values in a row.
+// Custom columns are visible by default, because the user has explicitly configured them.
+func customColumns(custom []api.CustomColumn, index int) []Column {
+	var columns []Column
+	for i, column := range custom {
+		width := column.Width
+		if width == 0 {
+			width = defaultCustomColumnWidth
+		}
+
+		columns = append(columns, Column{Name: column.Name, Header: column.Header, Index: index + i, Width: width, Visible: true})
+	}
+
+	return columns
+}
+
+// ConfigureColumns sets the visible columns, their order and their width from the configuration file.
+// Columns which are not configured are hidden, but they are kept at the end of the columns, so that they can be shown via the column picker.
+// Unknown columns in the configuration are ignored. If none of the configured columns is known, the columns are not changed, so that the table always shows at least one column.
+func (t *Table) ConfigureColumns(configured []config.Column) {
+	if len(configured) == 0 || ValidateColumns(t.Columns, configured) != nil {
+		return
+	}
+
+	var columns []Column
+	used := make(map[string]bool)
+
+	for _, c := range configured {
+		for _, column := range t.Columns {
+			if column.Name == c.Name && !used[column.Name] {
+				column.Visible = true
+				if c.Width > 0 {
+					column.Width = c.Width
+				}
+
+				columns = append(columns, column)
+				used[column.Name] = true
+			}
+		}
+	}
+
+	for _, column := range t.Columns {
+		if !used[column.Name] {
+			column.Visible = false
+			columns = append(columns, column)
+		}
+	}
+
+	t.Columns = columns
+}
+
+// layoutColumns calculates the header, the width and the order of all columns from the column model.
+// If the preferred widths of all visible columns are smaller than the width of the table, the remaining space is distributed between the flexible columns.
+// If the columns are larger than the table, all columns are shrunk proportionally, but not below the width of the header.
+// Columns which do not fit into the table even at their minimum width are counted, so that we can show an indicator in the header instead of dropping them silently.
+func (t *Table) layoutColumns() {
+	size := len(t.Header)
+	for _, column := range t.Columns {
+		if column.Index+1 > size {
+			size = column.Index + 1
+		}
+	}
+
+	header := make([]string, size)
+	for _, column := range t.Columns {
+		header[column.Index] = column.Header + t.sortIndicator(column.Name)
+	}
+
+	var visible []Column
+	for _, column := range t.Columns {
+		if column.Visible {
+			visible = append(visible, column)
+		}
+	}
+
+	available := t.Inner.Dx() - t.PadLeft - 1 - t.ColGap*len(visible)
+	widths := make([]int, len(visible))
+	minWidths := make([]int, len(visible))
+	total := 0
+	flexible := 0
+
+	for i, column := range visible {
+		widths[i] = column.Width
+		minWidths[i] = minColumnWidth
+		if len(header[column.Index])+1 > minWidths[i] {
+			minWidths[i] = len(header[column.Index]) + 1
+		}
+		if minWidths[i] > widths[i] {
+			minWidths[i] = widths[i]
+		}
+
+		total = total + widths[i]
+		if column.Flex {
+			flexible++
+		}
+	}
+
+	if total < available && len(visible) > 0 {
+		// Distribute the remaining space between the flexible columns.
+		// If there is no flexible column, the last column gets the remaining space.
+		extra := available - total
+		if flexible == 0 {
+			widths[len(widths)-1] = widths[len(widths)-1] + extra
+		} else {
+			for i, column := range visible {
+				if column.Flex {
+					widths[i] = widths[i] + extra/flexible
+				}
+			}
+		}
+	} else if total > available {
+		// Shrink all columns proportionally to their shrinkable space.
+		overflow := total - available
+		shrinkable := 0
+		for i := range visible {
+			shrinkable = shrinkable + widths[i] - minWidths[i]
+		}
+
+		if shrinkable > 0 {
+			reduce := overflow
+			if reduce > shrinkable {
+				reduce = shrinkable
+			}
+
+			reduced := 0
+			for i := range visible {
+				r := reduce * (widths[i] - minWidths[i]) / shrinkable
+				widths[i] = widths[i] - r
+				reduced = reduced + r
+			}
+
+			// Remove the rounding error from the columns, which can be shrunk further.
+			for i := len(visible) - 1; i >= 0 && reduced < reduce; i-- {
+				r := widths[i] - minWidths[i]
+				if r > reduce-reduced {
+					r = reduce - reduced
+				}
+				widths[i] = widths[i] - r
+				reduced = reduced + r
+			}
+		}
+	}
+
+	// Count the columns which do not fit into the table. The last column, which does not fit completely, is truncated.
+	colWidths := make([]int, size)
+	order := make([]int, 0, len(visible))
+	used := 0
+	t.hiddenCols = 0
+
+	for i, column := range visible {
+		remaining := available - used
+		if remaining < minWidths[i] {
+			t.hiddenCols = len(visible) - i
+			break
+		}
+		if widths[i] > remaining {
+			widths[i] = remaining
+		}
+
+		colWidths[column.Index] = widths[i]
+		order = append(order, column.Index)
+		used = used + widths[i]
+	}
+
+	t.Header = header
+	t.ColWidths = colWidths
+	t.ColOrder = order
+}
+
+// sortIndicator returns the indicator for the header of a sorted column.
+// The primary sort column is marked with a filled triangle, the secondary sort column with an empty triangle. The direction of the triangle shows the direction of the sortorder.
+func (t *Table) sortIndicator(column string) string {
+	switch column {
+	case t.Sort.Primary.Column:
+		if t.Sort.Primary.Desc {
+			return " ▼"
+		}
+		return " ▲"
+	case t.Sort.Secondary.Column:
+		if t.Sort.Secondary.Desc {
+			return " ▽"
+		}
+		return " △"
+	}
+
+	return ""
+}
+
+// HeaderColumnAt returns the name of the column at the provided position, if the position is in the header of the table.
+// This is used to sort the table by a column, when the user clicks on the header of the column. It is safe to call the method on a nil table.
+func (t *Table) HeaderColumnAt(x, y int) (string, bool) {
+	if t == nil || t.Columns == nil || y != t.Inner.Min.Y {
+		return "", false
+	}
+
+	cur := t.Inner.Min.X + t.PadLeft
+	for _, index := range t.ColOrder {
+		if x >= cur && x < cur+t.ColWidths[index] {
+			for _, column := range t.Columns {
+				if column.Index == index {
+					return column.Name, true
+				}
+			}
+		}
+
+		cur = cur + t.ColWidths[index] + t.ColGap
+	}
+
+	return "", false
+}
+
+// VisibleColumns returns the names of all visible columns in the order in which they are rendered.
+func (t *Table) VisibleColumns() []string {
+	var columns []string
+	for _, column := range t.Columns {
+		if column.Visible {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	return columns
+}
+
+// ToggleColumn shows or hides the column at the provided position.
+func (t *Table) ToggleColumn(position int) {
+	if position < 0 || position >= len(t.Columns) {
+		return
+	}
+
+	// The last visible column can not be hidden, because the table would only show the header bar otherwise.
+	if t.Columns[position].Visible && len(t.VisibleColumns()) <= 1 {
+		return
+	}
+
+	t.Columns[position].Visible = !t.Columns[position].Visible
+}
+
+// MoveColumn moves the column at the provided position by the provided offset.
+// It returns the new position of the column.
+func (t *Table) MoveColumn(position, offset int) int {
+	target := position + offset
+	if position < 0 || position >= len(t.Columns) || target < 0 || target >= len(t.Columns) {
+		return position
+	}
+
+	t.Columns[position], t.Columns[target] = t.Columns[target], t.Columns[position]
+	return target
+}
+
+// ResizeColumn changes the preferred width of the column at the provided position by the provided offset.
+func (t *Table) ResizeColumn(position, offset int) {
+	if position >= 0 && position < len(t.Columns) {
+		t.Columns[position].Width = t.Columns[position].Width + offset
+		if t.Columns[position].Width < minColumnWidth {
+			t.Columns[position].Width = minColumnWidth
+		}
+	}
+}
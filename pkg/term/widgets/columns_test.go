@@ -0,0 +1,107 @@
+package widgets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ricoberger/kubetop/pkg/config"
+)
+
+func TestLayoutColumns(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		width      int
+		columns    []Column
+		wantWidths []int
+		wantOrder  []int
+		wantHidden int
+	}{
+		{
+			"remaining space for flexible column", 50,
+			[]Column{{"a", "A", 0, 10, true, true}, {"b", "B", 1, 10, false, true}, {"c", "C", 2, 10, false, true}},
+			[]int{24, 10, 10}, []int{0, 1, 2}, 0,
+		},
+		{
+			"remaining space for last column", 50,
+			[]Column{{"a", "A", 0, 10, false, true}, {"b", "B", 1, 10, false, true}, {"c", "C", 2, 10, false, true}},
+			[]int{10, 10, 24}, []int{0, 1, 2}, 0,
+		},
+		{
+			"hidden and reordered columns", 50,
+			[]Column{{"c", "C", 2, 10, false, true}, {"b", "B", 1, 10, false, false}, {"a", "A", 0, 10, true, true}},
+			[]int{35, 0, 10}, []int{2, 0}, 0,
+		},
+		{
+			"shrink proportionally", 40,
+			[]Column{{"a", "A", 0, 20, false, true}, {"b", "B", 1, 10, false, true}, {"c", "C", 2, 10, false, true}},
+			[]int{17, 9, 8}, []int{0, 1, 2}, 0,
+		},
+		{
+			"columns which do not fit", 22,
+			[]Column{{"a", "A", 0, 10, false, true}, {"b", "B", 1, 10, false, true}, {"c", "C", 2, 10, false, true}},
+			[]int{6, 6, 0}, []int{0, 1}, 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable()
+			table.ColGap = 1
+			table.Columns = tt.columns
+			table.SetRect(0, 0, tt.width, 10)
+
+			table.layoutColumns()
+
+			if !reflect.DeepEqual(table.ColWidths, tt.wantWidths) {
+				t.Errorf("expected widths %v, got %v", tt.wantWidths, table.ColWidths)
+			}
+			if !reflect.DeepEqual(table.ColOrder, tt.wantOrder) {
+				t.Errorf("expected order %v, got %v", tt.wantOrder, table.ColOrder)
+			}
+			if table.hiddenCols != tt.wantHidden {
+				t.Errorf("expected %d hidden columns, got %d", tt.wantHidden, table.hiddenCols)
+			}
+		})
+	}
+}
+
+func TestConfigureColumns(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		configured  []config.Column
+		wantVisible []string
+	}{
+		{"not configured", nil, []string{"a", "b", "c"}},
+		{"reordered", []config.Column{{Name: "c"}, {Name: "a"}}, []string{"c", "a"}},
+		{"unknown columns are ignored", []config.Column{{Name: "b"}, {Name: "unknown"}}, []string{"b"}},
+		{"only unknown columns", []config.Column{{Name: "unknown"}}, []string{"a", "b", "c"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable()
+			table.Columns = []Column{{"a", "A", 0, 10, false, true}, {"b", "B", 1, 10, false, true}, {"c", "C", 2, 10, false, true}}
+
+			table.ConfigureColumns(tt.configured)
+
+			if got := table.VisibleColumns(); !reflect.DeepEqual(got, tt.wantVisible) {
+				t.Errorf("expected visible columns %v, got %v", tt.wantVisible, got)
+			}
+			if len(table.Columns) != 3 {
+				t.Errorf("expected all columns to be kept, got %d", len(table.Columns))
+			}
+		})
+	}
+}
+
+func TestToggleColumn(t *testing.T) {
+	table := NewTable()
+	table.Columns = []Column{{"a", "A", 0, 10, false, true}, {"b", "B", 1, 10, false, true}}
+
+	table.ToggleColumn(0)
+	table.ToggleColumn(1)
+	if got := table.VisibleColumns(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("expected the last visible column to be kept, got %v", got)
+	}
+
+	table.ToggleColumn(0)
+	if got := table.VisibleColumns(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("expected hidden column to be shown again, got %v", got)
+	}
+}
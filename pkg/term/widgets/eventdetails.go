@@ -17,7 +17,8 @@ type EventDetailsWidget struct {
 
 	eventDetails *w.Paragraph
 
-	apiClient *api.Client
+	apiClient api.Source
+	event     api.Event
 	filter    api.Filter
 	name      string
 	namespace string
@@ -26,7 +27,7 @@ type EventDetailsWidget struct {
 }
 
 // NewEventDetailsWidget returns an new event details widget.
-func NewEventDetailsWidget(name, namespace string, apiClient *api.Client, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *EventDetailsWidget {
+func NewEventDetailsWidget(name, namespace string, apiClient api.Source, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *EventDetailsWidget {
 	block := ui.NewBlock()
 	block.SetRect(0, 0, termWidth, termHeight)
 
@@ -38,6 +39,7 @@ func NewEventDetailsWidget(name, namespace string, apiClient *api.Client, filter
 		eventDetails,
 
 		apiClient,
+		api.Event{},
 		filter,
 		name,
 		namespace,
@@ -46,6 +48,10 @@ func NewEventDetailsWidget(name, namespace string, apiClient *api.Client, filter
 	}
 }
 
+// Close is not used, because the view does not run anything in the background.
+func (e *EventDetailsWidget) Close() {
+}
+
 // Filter returns the setted filter.
 func (e *EventDetailsWidget) Filter() api.Filter {
 	return e.filter
@@ -56,9 +62,9 @@ func (e *EventDetailsWidget) Pause() bool {
 	return e.pause
 }
 
-// SelectedValues returns the name of the selected pod.
+// SelectedValues returns the values of the event in the same format as they are returned by the events widget.
 func (e *EventDetailsWidget) SelectedValues() []string {
-	return []string{}
+	return eventRow(e.event)
 }
 
 // SelectNext selects the next log line.
@@ -104,6 +110,10 @@ func (e *EventDetailsWidget) Sortorder() api.Sort {
 	return e.sortorder
 }
 
+// ToggleFocus is not used, because the view contains only one focusable element.
+func (e *EventDetailsWidget) ToggleFocus() {
+}
+
 // TogglePause sets toggle pause.
 func (e *EventDetailsWidget) TogglePause() {
 	e.pause = !e.pause
@@ -111,11 +121,32 @@ func (e *EventDetailsWidget) TogglePause() {
 
 // Update updates the data for the details view of a pod.
 func (e *EventDetailsWidget) Update() error {
-	if !e.pause {
+	return update(e.Fetch())
+}
+
+// Fetch returns the function to get the details of the event.
+func (e *EventDetailsWidget) Fetch() FetchFunc {
+	if e.pause {
+		return fetchNothing
+	}
+
+	return func() (func(), error) {
 		event := e.apiClient.GetEvent(e.name, e.namespace)
 
-		e.eventDetails.Border = false
-		e.eventDetails.Text = fmt.Sprintf(`
+		return func() {
+			if !e.pause {
+				e.setEvent(event)
+			}
+		}, nil
+	}
+}
+
+// setEvent renders the details of the event.
+func (e *EventDetailsWidget) setEvent(event api.Event) {
+	e.event = event
+
+	e.eventDetails.Border = false
+	e.eventDetails.Text = fmt.Sprintf(`
 		UID:        %s
 		Name:       %s
 		Namespace:  %s
@@ -126,18 +157,44 @@ func (e *EventDetailsWidget) Update() error {
 		Count:      %d
 		Type:       %s
 		Kind:       %s
+		Object:     %s
 		Reason:     %s
 		Source:     %s
-		Message:    %s`, event.UID, event.Name, event.Namespace, event.Node, helpers.FormatDuration(time.Now().Sub(time.Unix(event.Timestamp, 0))), event.FirstTimestamp.Format("Mon, 02 Jan 2006 15:04:05 -0700"), event.LastTimestamp.Format("Mon, 02 Jan 2006 15:04:05 -0700"), event.Count, event.Type, event.Kind, event.Reason, event.Source, event.Message)
-
-		termWidth, termHeight := ui.TerminalDimensions()
-		e.eventDetails.SetRect(0, 0, termWidth, termHeight)
-	}
+		Reporter:   %s
+		Message:    %s`, event.UID, event.Name, event.Namespace, event.Node, helpers.FormatDuration(time.Now().Sub(time.Unix(event.Timestamp, 0))), event.FirstTimestamp.Format("Mon, 02 Jan 2006 15:04:05 -0700"), event.LastTimestamp.Format("Mon, 02 Jan 2006 15:04:05 -0700"), event.Count, event.Type, event.Kind, formatObjectReference(event.InvolvedObject), event.Reason, event.Source, formatReporter(event), event.Message)
 
-	return nil
+	e.eventDetails.SetRect(e.Min.X, e.Min.Y, e.Max.X, e.Max.Y)
 }
 
 // Draw renders our statusbar.
 func (e *EventDetailsWidget) Draw(buf *ui.Buffer) {
 	e.eventDetails.Draw(buf)
 }
+
+// formatObjectReference returns the involved object of an event in the format 'namespace/name (uid)'.
+func formatObjectReference(ref api.ObjectReference) string {
+	name := ref.Name
+	if ref.Namespace != "" {
+		name = ref.Namespace + "/" + ref.Name
+	}
+
+	if ref.UID == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, ref.UID)
+}
+
+// formatReporter returns the controller and the instance, which reported the event in the format 'controller (instance)'.
+// The reporter is only set for events, which were created via the events.k8s.io API.
+func formatReporter(event api.Event) string {
+	if event.ReportingController == "" {
+		return "-"
+	}
+
+	if event.ReportingInstance == "" {
+		return event.ReportingController
+	}
+
+	return fmt.Sprintf("%s (%s)", event.ReportingController, event.ReportingInstance)
+}
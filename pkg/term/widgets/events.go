@@ -2,6 +2,9 @@ package widgets
 
 import (
 	"fmt"
+	"image"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ricoberger/kubetop/pkg/api"
@@ -10,43 +13,86 @@ import (
 	ui "github.com/gizak/termui/v3"
 )
 
+const (
+	// eventGroupPrefix is the prefix for the key of a row, which represents a group of events.
+	eventGroupPrefix = "group:"
+	// tailIdleTimeout is the time after which the watch for the tail mode is stopped, when the widget was not updated.
+	// This happens when the events view is not shown anymore. The watch is resumed with the next update.
+	tailIdleTimeout = 30 * time.Second
+)
+
+// EventColumns are all available columns for the events view.
+// The namespace, the name and the uid of the involved object are only used to go to the involved object, so that only the object column is available.
+// The kind, reason, source, node and object columns are hidden by default and can be shown via the column picker or the configuration file.
+var EventColumns = []Column{
+	{Name: "age", Header: "AGE", Index: 0, Width: 10, Visible: true},
+	{Name: "count", Header: "COUNT", Index: 1, Width: 10, Visible: true},
+	{Name: "type", Header: "TYPE", Index: 2, Width: 10, Visible: true},
+	{Name: "namespace", Header: "NAMESPACE", Index: 3, Width: 20, Visible: true},
+	{Name: "name", Header: "NAME", Index: 4, Width: 50, Visible: true},
+	{Name: "message", Header: "MESSAGE", Index: 5, Width: 80, Flex: true, Visible: true},
+	{Name: "kind", Header: "KIND", Index: 6, Width: 15},
+	{Name: "reason", Header: "REASON", Index: 7, Width: 25},
+	{Name: "source", Header: "SOURCE", Index: 8, Width: 25},
+	{Name: "node", Header: "NODE", Index: 9, Width: 30},
+	{Name: "object", Header: "OBJECT", Index: 11, Width: 40},
+}
+
 // EventsWidget represents the ui widget component for the events view.
+// Besides the normal mode, where all events are listed on every update, the widget supports a tail mode.
+// In the tail mode the events are received via a watch and new events are added at the top of the table.
+// In both modes the events can be grouped by the kind of the involved object, the reason and the owner workload.
 type EventsWidget struct {
 	*Table
 
-	apiClient *api.Client
+	apiClient api.Source
 	filter    api.Filter
 	pause     bool
-	sortorder api.Sort
+
+	grouped  bool
+	expanded map[string]bool
+
+	tail            bool
+	tailRetention   int
+	tailEvents      []api.Event
+	tailNewEvents   int
+	tailMutex       sync.Mutex
+	tailGeneration  int
+	tailPending     []api.Event
+	tailStop        chan struct{}
+	tailLastUpdate  time.Time
+	resourceVersion string
 }
 
 // NewEventsWidget returns a new events widget.
 // We create the table for the events widget with all the basic layout settings.
-func NewEventsWidget(apiClient *api.Client, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *EventsWidget {
+// The tail retention is the maximum number of events which are kept in the tail mode.
+func NewEventsWidget(apiClient api.Source, filter api.Filter, sortorder api.Sort, tailRetention int, termWidth, termHeight int) *EventsWidget {
 	table := NewTable()
-	table.Header = []string{"", "AGE", "COUNT", "TYPE", "NAMESPACE", "NAME", "MESSAGE", "", "", "", ""}
-	table.UniqueCol = 0
+	table.Columns = ViewColumns(ViewTypeEvents, apiClient)
+	table.Sort = sortorder
 
 	table.SetRect(0, 0, termWidth, termHeight)
 
-	table.ColWidths = []int{0, 10, 10, 10, 20, 50, helpers.MaxInt(table.Inner.Dx()-100, 80), 0, 0, 0, 0}
-	table.ColResizer = func() {
-		table.ColWidths = []int{0, 10, 10, 10, 20, 50, helpers.MaxInt(table.Inner.Dx()-100, 80), 0, 0, 0, 0}
-	}
-
 	table.Border = false
 	table.BorderStyle = ui.NewStyle(ui.ColorClear)
 
 	return &EventsWidget{
-		table,
+		Table: table,
 
-		apiClient,
-		filter,
-		false,
-		sortorder,
+		apiClient:     apiClient,
+		filter:        filter,
+		pause:         false,
+		expanded:      make(map[string]bool),
+		tailRetention: tailRetention,
 	}
 }
 
+// Close stops the watch of the tail mode, when the view is not used anymore.
+func (e *EventsWidget) Close() {
+	e.stopTail()
+}
+
 // Filter returns the setted filter.
 func (e *EventsWidget) Filter() api.Filter {
 	return e.filter
@@ -59,58 +105,75 @@ func (e *EventsWidget) Pause() bool {
 
 // SelectedValues returns the selected event.
 func (e *EventsWidget) SelectedValues() []string {
-	return e.Rows[e.SelectedRow]
+	return e.SelectedRowValues()
 }
 
 // SelectNext selects the next item in the table.
 func (e *EventsWidget) SelectNext() {
 	e.ScrollDown()
+	e.tailNewEvents = 0
 }
 
 // SelectPrev selects the previous item in the table.
 func (e *EventsWidget) SelectPrev() {
 	e.ScrollUp()
+	e.tailNewEvents = 0
 }
 
 // SelectTop selects the top item in the table.
 func (e *EventsWidget) SelectTop() {
 	e.ScrollTop()
+	e.tailNewEvents = 0
 }
 
 // SelectBottom selects the bottom item in the table.
 func (e *EventsWidget) SelectBottom() {
 	e.ScrollBottom()
+	e.tailNewEvents = 0
 }
 
 // SelectHalfPageDown selects the item a half page down.
 func (e *EventsWidget) SelectHalfPageDown() {
 	e.ScrollHalfPageDown()
+	e.tailNewEvents = 0
 }
 
 // SelectHalfPageUp selects the item a half page up.
 func (e *EventsWidget) SelectHalfPageUp() {
 	e.ScrollHalfPageUp()
+	e.tailNewEvents = 0
 }
 
 // SelectPageDown selects the item on the next page.
 func (e *EventsWidget) SelectPageDown() {
 	e.ScrollPageDown()
+	e.tailNewEvents = 0
 }
 
 // SelectPageUp selects the item on the previous page.
 func (e *EventsWidget) SelectPageUp() {
 	e.ScrollPageUp()
+	e.tailNewEvents = 0
 }
 
 // SetSortAndFilter sets a new value for the sortorder and filter.
+// In the tail mode we have to start a new watch, because the filter is used to select the events.
 func (e *EventsWidget) SetSortAndFilter(sortorder api.Sort, filter api.Filter) {
-	e.sortorder = sortorder
+	e.Sort = sortorder
 	e.filter = filter
+
+	if e.tail {
+		e.stopTail()
+	}
 }
 
 // Sortorder returns the setted sortorder.
 func (e *EventsWidget) Sortorder() api.Sort {
-	return e.sortorder
+	return e.Sort
+}
+
+// ToggleFocus is not used, because the view contains only one focusable element.
+func (e *EventsWidget) ToggleFocus() {
 }
 
 // TogglePause sets toggle pause.
@@ -118,33 +181,351 @@ func (e *EventsWidget) TogglePause() {
 	e.pause = !e.pause
 }
 
-// Update updates the table data of the pod view.
-// Get the data for the pods widget and add each pod as seperate row to the table.
+// ToggleGroup expands or collapses the selected group of events.
+// It returns false when the events are not grouped or when the selected row is not a group, so that the details for the selected event can be shown.
+func (e *EventsWidget) ToggleGroup() bool {
+	if !e.grouped {
+		return false
+	}
+
+	if !e.SelectedIsGroup() {
+		return false
+	}
+
+	key := e.RowKey(e.SelectedRow)
+	e.expanded[key] = !e.expanded[key]
+	return true
+}
+
+// SelectedIsGroup returns true if the selected row is a group of events.
+func (e *EventsWidget) SelectedIsGroup() bool {
+	return strings.HasPrefix(e.RowKey(e.SelectedRow), eventGroupPrefix)
+}
+
+// ToggleGrouped switches between the list of all events and the grouped events.
+func (e *EventsWidget) ToggleGrouped() {
+	e.grouped = !e.grouped
+	e.expanded = make(map[string]bool)
+	e.SelectedRow = 0
+	e.TopRow = 0
+}
+
+// ToggleTail switches between the normal mode and the tail mode.
+func (e *EventsWidget) ToggleTail() {
+	e.tail = !e.tail
+	e.stopTail()
+	e.SelectedRow = 0
+	e.TopRow = 0
+}
+
+// Update updates the table data of the events view.
 func (e *EventsWidget) Update() error {
-	if !e.pause {
-		events, err := e.apiClient.GetEvents(e.filter, e.sortorder)
+	return update(e.Fetch())
+}
+
+// Fetch returns the function to get the data for the events widget. In the tail mode the events are received via the watch.
+// If the events are grouped, the groups are built while the events are fetched, because the owners of the involved objects are fetched from the Kubernetes API.
+// The events are only shown, when the filter, the sortorder and the grouping were not changed while they were fetched.
+func (e *EventsWidget) Fetch() FetchFunc {
+	if e.tail {
+		return e.fetchTail()
+	}
+
+	if e.pause {
+		return fetchNothing
+	}
+
+	filter, sortorder, grouped := e.filter, e.Sort, e.grouped
+	return func() (func(), error) {
+		events, err := e.apiClient.GetEvents(filter, sortorder)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		var groups []api.EventGroup
+		if grouped {
+			groups = e.apiClient.GroupEvents(events)
 		}
 
+		return func() {
+			if !e.tail && !e.pause && e.filter == filter && e.Sort == sortorder && e.grouped == grouped {
+				e.setRows(events, groups)
+			}
+		}, nil
+	}
+}
+
+// setRows sets the rows of the table for the provided events.
+// The uid of an event is used as key of the row. If the events are grouped, we add a row for each of the provided groups and the rows for the events of all expanded groups.
+func (e *EventsWidget) setRows(events []api.Event, groups []api.EventGroup) {
+	if !e.grouped {
 		rows := make([][]string, len(events))
+		keys := make([]string, len(events))
 		for i, event := range events {
-			rows[i] = make([]string, 11)
-			rows[i][0] = event.UID
-			rows[i][1] = helpers.FormatDuration(time.Now().Sub(time.Unix(event.Timestamp, 0)))
-			rows[i][2] = fmt.Sprintf("%d", event.Count)
-			rows[i][3] = event.Type
-			rows[i][4] = event.Namespace
-			rows[i][5] = event.Name
-			rows[i][6] = event.Message
-			rows[i][7] = event.Kind
-			rows[i][8] = event.Reason
-			rows[i][9] = event.Source
-			rows[i][10] = event.Node
+			rows[i] = eventRow(event)
+			keys[i] = event.UID
+		}
+
+		e.SetRows(rows, keys)
+		return
+	}
+
+	var rows [][]string
+	var keys []string
+	for _, group := range groups {
+		key := eventGroupPrefix + group.Key
+		rows = append(rows, eventGroupRow(group, e.expanded[key]))
+		keys = append(keys, key)
+
+		if e.expanded[key] {
+			for _, event := range group.Events {
+				child := eventRow(event)
+				child[5] = "  └ " + child[5]
+				rows = append(rows, child)
+				keys = append(keys, event.UID)
+			}
+		}
+	}
+
+	e.SetRows(rows, keys)
+}
+
+// Draw renders the events table.
+// In the tail mode we also render an indicator with the number of events, which were received since the user scrolled the last time.
+func (e *EventsWidget) Draw(buf *ui.Buffer) {
+	e.Table.Draw(buf)
+
+	if e.tail {
+		indicator := fmt.Sprintf(" TAIL: %d new ", e.tailNewEvents)
+		buf.SetString(
+			indicator,
+			ui.NewStyle(ui.ColorBlack, ui.ColorYellow),
+			image.Pt(e.Inner.Max.X-len(indicator), e.Inner.Min.Y),
+		)
+	}
+}
+
+// fetchTail returns the function to get the table data in the tail mode.
+// If the watch is not running, we get the current events and start a new watch at the resource version of the returned list.
+// Then we add all events, which were received since the last update, at the top of the events.
+// Events which were updated (e.g. the count was increased) are moved to the top.
+// The generation of the tail mode is changed when the watch is stopped, so that the events of an old watch are neither saved nor shown.
+func (e *EventsWidget) fetchTail() FetchFunc {
+	filter, grouped, pause := e.filter, e.grouped, e.pause
+
+	e.tailMutex.Lock()
+	generation := e.tailGeneration
+	e.tailMutex.Unlock()
+
+	return func() (func(), error) {
+		e.tailMutex.Lock()
+		e.tailLastUpdate = time.Now()
+		running, listed := e.tailStop != nil, e.tailEvents != nil
+		e.tailMutex.Unlock()
+
+		if !running {
+			if !listed {
+				events, resourceVersion, err := e.apiClient.ListEvents(filter, api.NewSort("age", false))
+				if err != nil {
+					return nil, err
+				}
+
+				// The watch starts at the resource version of the list, so that events which are fired between the list and the watch are not missed.
+				e.tailMutex.Lock()
+				if e.tailGeneration == generation && e.tailEvents == nil {
+					e.tailEvents = append([]api.Event{}, events[:helpers.MinInt(len(events), e.tailRetention)]...)
+					e.resourceVersion = resourceVersion
+				}
+				e.tailMutex.Unlock()
+			}
+
+			e.startTail(filter, generation)
 		}
 
-		e.Rows = rows
+		if pause {
+			return func() {}, nil
+		}
+
+		e.tailMutex.Lock()
+		if e.tailGeneration != generation {
+			e.tailMutex.Unlock()
+			return func() {}, nil
+		}
+
+		pending := e.tailPending
+		e.tailPending = nil
+
+		for _, event := range pending {
+			for i, tailEvent := range e.tailEvents {
+				if tailEvent.UID == event.UID {
+					e.tailEvents = append(e.tailEvents[:i], e.tailEvents[i+1:]...)
+					break
+				}
+			}
+
+			e.tailEvents = append([]api.Event{event}, e.tailEvents...)
+		}
+
+		if len(e.tailEvents) > e.tailRetention {
+			e.tailEvents = e.tailEvents[:e.tailRetention]
+		}
+
+		events := append([]api.Event{}, e.tailEvents...)
+		e.tailMutex.Unlock()
+
+		var groups []api.EventGroup
+		if grouped {
+			groups = e.apiClient.GroupEvents(events)
+		}
+
+		return func() {
+			e.tailMutex.Lock()
+			current := e.tailGeneration == generation
+			e.tailMutex.Unlock()
+
+			if current && e.tail && !e.pause && e.grouped == grouped {
+				e.setTail(events, groups, len(pending))
+			}
+		}, nil
 	}
+}
+
+// setTail shows the events of the tail mode.
+// When the user has scrolled away from the top, we keep the selected event at the same position on the screen.
+func (e *EventsWidget) setTail(events []api.Event, groups []api.EventGroup, newEvents int) {
+	selectedUID := e.RowKey(e.SelectedRow)
+	selectedOffset := e.SelectedRow - e.TopRow
+
+	e.tailNewEvents = e.tailNewEvents + newEvents
+
+	selectedRow := e.SelectedRow
+	e.setRows(events, groups)
+
+	if selectedRow > 0 {
+		for i := range e.Rows {
+			if e.RowKey(i) == selectedUID {
+				e.SelectedRow = i
+				e.TopRow = helpers.MaxInt(i-selectedOffset, 0)
+				break
+			}
+		}
+	} else {
+		e.SelectedRow = 0
+		e.TopRow = 0
+		e.tailNewEvents = 0
+	}
+}
+
+// startTail starts a new watch for events with the provided filter.
+// The watch is resumed from the resource version of the last received event. It is not started, when the watch is already running or the generation of the tail mode was changed.
+// The received events are collected until the next update of the widget.
+// If the widget was not updated for some time, the watch is stopped.
+func (e *EventsWidget) startTail(filter api.Filter, generation int) {
+	e.tailMutex.Lock()
+	if e.tailStop != nil || e.tailGeneration != generation {
+		e.tailMutex.Unlock()
+		return
+	}
+
+	stop := make(chan struct{})
+	e.tailStop = stop
+	resourceVersion := e.resourceVersion
+	e.tailMutex.Unlock()
+
+	events := e.apiClient.WatchEvents(filter, resourceVersion, stop)
+
+	go func() {
+		ticker := time.NewTicker(tailIdleTimeout / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				// The received events are not taken while the view is paused, so that we only keep the newest events up to the retention.
+				e.tailMutex.Lock()
+				if e.tailStop == stop {
+					e.tailPending = append(e.tailPending, event)
+					if len(e.tailPending) > e.tailRetention {
+						e.tailPending = e.tailPending[len(e.tailPending)-e.tailRetention:]
+					}
+					e.resourceVersion = event.ResourceVersion
+				}
+				e.tailMutex.Unlock()
+			case <-ticker.C:
+				e.tailMutex.Lock()
+				if e.tailStop == stop && time.Now().Sub(e.tailLastUpdate) > tailIdleTimeout {
+					close(stop)
+					e.tailStop = nil
+				}
+				e.tailMutex.Unlock()
+			}
+		}
+	}()
+}
+
+// stopTail stops the watch for events and removes all received events.
+func (e *EventsWidget) stopTail() {
+	e.tailMutex.Lock()
+	defer e.tailMutex.Unlock()
+
+	if e.tailStop != nil {
+		close(e.tailStop)
+		e.tailStop = nil
+	}
+
+	e.tailGeneration++
+	e.tailEvents = nil
+	e.tailPending = nil
+	e.tailNewEvents = 0
+	e.resourceVersion = ""
+}
+
+// eventRow returns the values of an event as table row.
+// The columns after the message are not rendered, but they are needed to open the details of the event and to jump to the involved object.
+func eventRow(event api.Event) []string {
+	row := make([]string, 13)
+	row[0] = helpers.FormatDuration(time.Now().Sub(time.Unix(event.Timestamp, 0)))
+	row[1] = fmt.Sprintf("%d", event.Count)
+	row[2] = event.Type
+	row[3] = event.Namespace
+	row[4] = event.Name
+	row[5] = event.Message
+	row[6] = event.InvolvedObject.Kind
+	row[7] = event.Reason
+	row[8] = event.Source
+	row[9] = event.Node
+	row[10] = event.InvolvedObject.Namespace
+	row[11] = event.InvolvedObject.Name
+	row[12] = event.InvolvedObject.UID
+
+	return row
+}
+
+// eventGroupRow returns the values of a group of events as table row.
+// The row has the same format as the row of a single event, so that we can jump to the owner workload of the group.
+// Instead of the name of the event we show the owner and the message contains the reason and the number of events in the group.
+func eventGroupRow(group api.EventGroup, expanded bool) []string {
+	indicator := "[+]"
+	if expanded {
+		indicator = "[-]"
+	}
+
+	row := make([]string, 13)
+	row[0] = helpers.FormatDuration(time.Now().Sub(group.LastTimestamp))
+	row[1] = fmt.Sprintf("%d", group.Count)
+	row[2] = group.Type
+	row[3] = group.Namespace
+	row[4] = group.Owner.Kind + "/" + group.Owner.Name
+	row[5] = fmt.Sprintf("%s %s: %s (%d events, first seen %s ago)", indicator, group.Reason, group.Message, len(group.Events), helpers.FormatDuration(time.Now().Sub(group.FirstTimestamp)))
+	row[6] = group.Owner.Kind
+	row[7] = group.Reason
+	row[10] = group.Owner.Namespace
+	row[11] = group.Owner.Name
+	row[12] = group.Owner.UID
 
-	return nil
+	return row
 }
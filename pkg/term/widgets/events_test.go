@@ -0,0 +1,108 @@
+package widgets
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+)
+
+// tailSource returns the events which are sent to the events channel for the watch of the tail mode.
+// All other methods of the source are not used by the tests and panic.
+type tailSource struct {
+	api.Source
+
+	events chan api.Event
+	stop   <-chan struct{}
+}
+
+func (s *tailSource) ListEvents(filter api.Filter, sortorder api.Sort) ([]api.Event, string, error) {
+	return nil, "0", nil
+}
+
+func (s *tailSource) WatchEvents(filter api.Filter, resourceVersion string, stop <-chan struct{}) <-chan api.Event {
+	s.stop = stop
+	return s.events
+}
+
+func newTailWidget(retention int) (*EventsWidget, *tailSource) {
+	source := &tailSource{events: make(chan api.Event)}
+	widget := NewEventsWidget(source, api.Filter{Status: 10}, api.NewSort("age", false), retention, 100, 20)
+	widget.ToggleTail()
+
+	return widget, source
+}
+
+// waitForResourceVersion waits until the watch of the tail mode has received the event with the provided resource version.
+func waitForResourceVersion(t *testing.T, widget *EventsWidget, resourceVersion string) {
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		widget.tailMutex.Lock()
+		received := widget.resourceVersion == resourceVersion
+		widget.tailMutex.Unlock()
+
+		if received {
+			return
+		}
+	}
+
+	t.Fatalf("expected event with resource version %s", resourceVersion)
+}
+
+func TestTailPaused(t *testing.T) {
+	widget, source := newTailWidget(5)
+	defer widget.Close()
+
+	widget.TogglePause()
+	if err := widget.Update(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i := 1; i <= 20; i++ {
+		source.events <- api.Event{UID: fmt.Sprintf("event-%d", i), ResourceVersion: fmt.Sprintf("%d", i)}
+	}
+	waitForResourceVersion(t, widget, "20")
+
+	widget.tailMutex.Lock()
+	pending := len(widget.tailPending)
+	widget.tailMutex.Unlock()
+	if pending != 5 {
+		t.Errorf("expected 5 pending events while paused, got %d", pending)
+	}
+
+	widget.TogglePause()
+	if err := widget.Update(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(widget.Rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(widget.Rows))
+	}
+	for i := 0; i < 5; i++ {
+		if key, expected := widget.RowKey(i), fmt.Sprintf("event-%d", 20-i); key != expected {
+			t.Errorf("expected row %d to be %s, got %s", i, expected, key)
+		}
+	}
+}
+
+func TestTailClose(t *testing.T) {
+	widget, source := newTailWidget(5)
+
+	if err := widget.Update(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	widget.Close()
+
+	select {
+	case <-source.stop:
+	default:
+		t.Errorf("expected watch to be stopped")
+	}
+
+	widget.tailMutex.Lock()
+	defer widget.tailMutex.Unlock()
+	if widget.tailStop != nil || widget.tailEvents != nil {
+		t.Errorf("expected tail mode to be reset")
+	}
+}
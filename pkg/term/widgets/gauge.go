@@ -0,0 +1,111 @@
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// GaugeMode is our custom type for the different ways how utilisation columns are rendered.
+type GaugeMode string
+
+const (
+	// GaugeModeBars renders utilisation columns as inline bar gauges.
+	GaugeModeBars GaugeMode = "Bars"
+	// GaugeModePercent renders utilisation columns as percentages.
+	GaugeModePercent GaugeMode = "Percent"
+	// GaugeModeAbsolute renders utilisation columns as absolute values.
+	GaugeModeAbsolute GaugeMode = "Absolute"
+)
+
+// Next returns the next gauge mode, so that the user can cycle through all modes with a single key.
+func (m GaugeMode) Next() GaugeMode {
+	switch m {
+	case GaugeModeBars:
+		return GaugeModePercent
+	case GaugeModePercent:
+		return GaugeModeAbsolute
+	default:
+		return GaugeModeBars
+	}
+}
+
+// Thresholds contains the percentages at which a gauge is colored yellow (warning) or red (critical).
+// Below the warning threshold a gauge is colored green.
+type Thresholds struct {
+	Warning  float64
+	Critical float64
+}
+
+// Validate returns an error if the thresholds are not percentages or if the warning threshold is greater than the critical threshold.
+func (t Thresholds) Validate() error {
+	if t.Warning < 0 || t.Critical > 100 || t.Warning > t.Critical {
+		return fmt.Errorf("invalid gauge thresholds %g and %g: must be 0 <= warning <= critical <= 100", t.Warning, t.Critical)
+	}
+
+	return nil
+}
+
+// color returns the color for the provided percentage.
+func (t Thresholds) color(percent float64) ui.Color {
+	if percent >= t.Critical {
+		return ui.ColorRed
+	} else if percent >= t.Warning {
+		return ui.ColorYellow
+	}
+
+	return ui.ColorGreen
+}
+
+// formatPercent returns the percentage of the used value from the total value for a gauge column.
+// If the total value is zero (e.g. no limit is set) the fallback value is returned, which is rendered as normal text.
+func formatPercent(used, total int64, fallback string) string {
+	if total == 0 {
+		return fallback
+	}
+
+	return fmt.Sprintf("%.2f%%", float64(used)*100.0/float64(total))
+}
+
+// parsePercent returns the percentage of a cell in a gauge column.
+// The second return value is false, if the cell does not contain a percentage.
+func parsePercent(value string) (float64, bool) {
+	if !strings.HasSuffix(value, "%") {
+		return 0, false
+	}
+
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return percent, true
+}
+
+// drawGauge renders a cell of a gauge column.
+// In the bars mode we render a bar followed by the percentage, the bar is filled according to the percentage.
+// If the column is too small for a bar or the percent mode is used, we only render the percentage.
+// The bar and the percentage are colored by the thresholds, the background is taken from the style of the row.
+func (t *Table) drawGauge(buf *ui.Buffer, percent float64, width int, style ui.Style, point image.Point) {
+	text := fmt.Sprintf("%.1f%%", percent)
+	style.Fg = t.GaugeThresholds.color(percent)
+
+	barWidth := width - len(text) - 4
+	if t.GaugeMode != GaugeModeBars || barWidth < 3 {
+		buf.SetString(ui.TrimString(text, width), style, point)
+		return
+	}
+
+	filled := int(percent / 100.0 * float64(barWidth))
+	if filled < 0 {
+		filled = 0
+	} else if filled > barWidth {
+		filled = barWidth
+	}
+
+	bar := "[" + strings.Repeat("|", filled) + strings.Repeat(" ", barWidth-filled) + "] " + text
+	buf.SetString(bar, style, point)
+}
@@ -0,0 +1,60 @@
+package widgets
+
+import (
+	"fmt"
+
+	w "github.com/gizak/termui/v3/widgets"
+)
+
+// HelpWidget represents the modal, which shows the keys which can be used in the current view.
+// The rows of the help are generated from the keybinding registry, so that the help always shows the keys which can be used.
+type HelpWidget struct {
+	*w.List
+
+	active bool
+}
+
+// NewHelpWidget returns a new help widget.
+func NewHelpWidget() *HelpWidget {
+	list := w.NewList()
+	list.TextStyle = theme.List
+	list.WrapText = false
+
+	return &HelpWidget{
+		list,
+
+		false,
+	}
+}
+
+// Active returns if the help is shown.
+func (h *HelpWidget) Active() bool {
+	return h.active
+}
+
+// Show shows the help with the provided rows. Each row contains the keys and the description of an action.
+// The keys are aligned, so that all descriptions start at the same position.
+func (h *HelpWidget) Show(title string, rows [][]string, termWidth, termHeight int) {
+	var width int
+	for _, row := range rows {
+		if len(row[0]) > width {
+			width = len(row[0])
+		}
+	}
+
+	h.Title = title
+	h.Rows = []string{}
+	for _, row := range rows {
+		h.Rows = append(h.Rows, fmt.Sprintf("%-*s  %s", width, row[0], row[1]))
+	}
+
+	h.active = true
+	h.SelectedRow = 0
+	h.SetRect(termWidth/2-40, termHeight/2-12, termWidth/2+40, termHeight/2+12)
+}
+
+// Hide hides the help.
+func (h *HelpWidget) Hide() {
+	h.active = false
+	h.SetRect(0, 0, 0, 0)
+}
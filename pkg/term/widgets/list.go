@@ -2,7 +2,9 @@ package widgets
 
 import (
 	"fmt"
+	"image"
 
+	"github.com/ricoberger/kubetop/pkg/alerts"
 	"github.com/ricoberger/kubetop/pkg/api"
 
 	ui "github.com/gizak/termui/v3"
@@ -15,6 +17,8 @@ type ListType string
 const (
 	// ListTypeSort represents the the sorting list.
 	ListTypeSort ListType = "Sort by ..."
+	// ListTypeSortSecondary represents the list for the secondary sortorder.
+	ListTypeSortSecondary ListType = "Then sort by ..."
 	// ListTypeFilterNamespace represents the namespace filter.
 	ListTypeFilterNamespace ListType = "Filter by Namespace ..."
 	// ListTypeFilterNode represents the node filter.
@@ -23,6 +27,10 @@ const (
 	ListTypeFilterStatus ListType = "Filter by Status ..."
 	// ListTypeFilterEventType represents the event type filter.
 	ListTypeFilterEventType ListType = "Filter by Event Type ..."
+	// ListTypeFilterReason represents the reason filter for events.
+	ListTypeFilterReason ListType = "Filter by Reason ..."
+	// ListTypeAlerts represents the list of all firing alerts.
+	ListTypeAlerts ListType = "Alerts ..."
 	// ListTypeView represents the list for switching to an other view.
 	ListTypeView = "Select View ..."
 )
@@ -31,36 +39,75 @@ const (
 type ListWidget struct {
 	*w.List
 
-	apiClient        *api.Client
+	apiClient        api.Source
+	alerts           *alerts.Engine
 	filterNamespaces []string
 	filterNodes      []string
 	filterStatuses   []string
 	filterEventTypes []string
-	sortNodes        []api.Sort
-	sortPods         []api.Sort
-	sortEvents       []api.Sort
+	filterReasons    []string
+	sortNodes        []Column
+	sortPods         []Column
+	sortEvents       []Column
 	views            []ViewType
+	topRow           int
 }
 
 // NewListWidget returns a new list widget.
-func NewListWidget(apiClient *api.Client) *ListWidget {
+// The alerts engine is optional and only used to render the list of firing alerts.
+func NewListWidget(apiClient api.Source, alertsEngine *alerts.Engine) *ListWidget {
 	list := w.NewList()
-	list.TextStyle = ui.NewStyle(ui.ColorYellow)
+	list.TextStyle = theme.List
 	list.WrapText = false
 
+	// All columns of a view can be used for sorting, including the hidden and the custom columns of the pods and nodes view.
+	sortNodes := append(CopyColumns(NodeColumns), customColumns(apiClient.NodeCustomColumns(), len(NodeColumns))...)
+	sortPods := append(CopyColumns(PodColumns), customColumns(apiClient.PodCustomColumns(), len(PodColumns))...)
+
 	return &ListWidget{
 		list,
 
 		apiClient,
+		alertsEngine,
 		[]string{},
 		[]string{},
 		[]string{"-", "Running", "Waiting", "Terminated"},
 		[]string{"-", "Normal", "Warning"},
-		[]api.Sort{api.SortCPUASC, api.SortCPUDESC, api.SortMemoryASC, api.SortMemoryDESC, api.SortName, api.SortPodsASC, api.SortPodsDESC},
-		[]api.Sort{api.SortCPUASC, api.SortCPUDESC, api.SortMemoryASC, api.SortMemoryDESC, api.SortName, api.SortNamespace, api.SortRestartsASC, api.SortRestartsDESC, api.SortStatus},
-		[]api.Sort{api.SortName, api.SortNamespace, api.SortTimeASC, api.SortTimeDESC},
+		[]string{},
+		sortNodes,
+		sortPods,
+		CopyColumns(EventColumns),
 		[]ViewType{ViewTypePods, ViewTypeNodes, ViewTypeEvents},
+		0,
+	}
+}
+
+// Draw renders the list.
+// The first visible row of the list is not exported by termui, so we calculate it in the same way as termui, to know which row was clicked.
+func (l *ListWidget) Draw(buf *ui.Buffer) {
+	if l.SelectedRow >= l.Inner.Dy()+l.topRow {
+		l.topRow = l.SelectedRow - l.Inner.Dy() + 1
+	} else if l.SelectedRow < l.topRow {
+		l.topRow = l.SelectedRow
+	}
+
+	l.List.Draw(buf)
+}
+
+// HandleClick selects the row of the list at the provided position.
+// It returns false if the position is not a row of the list.
+func (l *ListWidget) HandleClick(x, y int) bool {
+	if !image.Pt(x, y).In(l.Inner) {
+		return false
+	}
+
+	row := l.topRow + y - l.Inner.Min.Y
+	if row >= len(l.Rows) {
+		return false
 	}
+
+	l.SelectedRow = row
+	return true
 }
 
 // Hide hides the list.
@@ -70,14 +117,12 @@ func (l *ListWidget) Hide() {
 
 // Selected determines the selected sortorder or filter.
 func (l *ListWidget) Selected(viewType ViewType, listType ListType, sortorder api.Sort, filter api.Filter) (ViewType, api.Sort, api.Filter) {
-	if viewType == ViewTypeNodes {
-		if listType == ListTypeSort {
-			sortorder = l.sortNodes[l.SelectedRow]
-		}
-	} else if viewType == ViewTypePods {
-		if listType == ListTypeSort {
-			sortorder = l.sortPods[l.SelectedRow]
-		} else if listType == ListTypeFilterNamespace {
+	if listType == ListTypeSort || listType == ListTypeSortSecondary {
+		sortorder = l.selectedSort(viewType, listType, sortorder)
+	}
+
+	if viewType == ViewTypePods {
+		if listType == ListTypeFilterNamespace {
 			if l.filterNamespaces[l.SelectedRow] == "-" {
 				filter.Namespace = ""
 			} else {
@@ -102,9 +147,7 @@ func (l *ListWidget) Selected(viewType ViewType, listType ListType, sortorder ap
 			}
 		}
 	} else if viewType == ViewTypeEvents {
-		if listType == ListTypeSort {
-			sortorder = l.sortEvents[l.SelectedRow]
-		} else if listType == ListTypeFilterNamespace {
+		if listType == ListTypeFilterNamespace {
 			if l.filterNamespaces[l.SelectedRow] == "-" {
 				filter.Namespace = ""
 			} else {
@@ -122,6 +165,12 @@ func (l *ListWidget) Selected(viewType ViewType, listType ListType, sortorder ap
 			} else {
 				filter.EventType = l.filterEventTypes[l.SelectedRow]
 			}
+		} else if listType == ListTypeFilterReason {
+			if l.filterReasons[l.SelectedRow] == "-" {
+				filter.Reason = ""
+			} else {
+				filter.Reason = l.filterReasons[l.SelectedRow]
+			}
 		}
 	}
 
@@ -133,32 +182,75 @@ func (l *ListWidget) Selected(viewType ViewType, listType ListType, sortorder ap
 	return viewType, sortorder, filter
 }
 
+// selectedSort returns the sortorder for the selected column.
+// When the column is selected in the sort list, the data is sorted by this column. If the data is already sorted by the column, the direction is toggled.
+// When the column is selected in the secondary sort list, it is used as secondary key. The first entry of the secondary sort list removes the secondary key.
+func (l *ListWidget) selectedSort(viewType ViewType, listType ListType, sortorder api.Sort) api.Sort {
+	columns := l.sortColumns(viewType)
+
+	if listType == ListTypeSortSecondary {
+		if l.SelectedRow == 0 {
+			return sortorder.ThenBy("")
+		}
+
+		return sortorder.ThenBy(columns[l.SelectedRow-1].Name)
+	}
+
+	return sortorder.SortBy(columns[l.SelectedRow].Name)
+}
+
+// sortColumns returns the columns which can be used for sorting in the provided view.
+func (l *ListWidget) sortColumns(viewType ViewType) []Column {
+	switch viewType {
+	case ViewTypeNodes:
+		return l.sortNodes
+	case ViewTypePods:
+		return l.sortPods
+	case ViewTypeEvents:
+		return l.sortEvents
+	}
+
+	return nil
+}
+
+// sortRows returns the rows for the sort list and the secondary sort list.
+// The first row of the secondary sort list is used to remove the secondary sortorder.
+func (l *ListWidget) sortRows(viewType ViewType, listType ListType) []string {
+	var rows []string
+	var offset int
+
+	if listType == ListTypeSortSecondary {
+		rows = append(rows, "[0] -")
+		offset = 1
+	}
+
+	for index, column := range l.sortColumns(viewType) {
+		rows = append(rows, fmt.Sprintf("[%d] %s", index+offset, column.Name))
+	}
+
+	return rows
+}
+
 // Show shows a list with the specified sort options or filters.
-func (l *ListWidget) Show(viewType ViewType, listType ListType, termWidth, termHeight int) bool {
+// The namespace is used to only show the reasons of events in the selected namespace.
+func (l *ListWidget) Show(viewType ViewType, listType ListType, namespace string, termWidth, termHeight int) bool {
 	var showList bool
 
 	l.Title = string(listType)
 	l.Rows = []string{}
 
-	if viewType == ViewTypeNodes {
-		// For the node view we only render the sort list.
-		if listType == ListTypeSort {
+	if listType == ListTypeSort || listType == ListTypeSortSecondary {
+		// The sort lists are rendered for the nodes, pods and events view. For all other views there are no sortable columns.
+		if l.sortColumns(viewType) != nil {
 			showList = true
-
-			for index, item := range l.sortNodes {
-				l.Rows = append(l.Rows, fmt.Sprintf("[%d] %s", index, item))
-			}
+			l.Rows = l.sortRows(viewType, listType)
 		}
-	} else if viewType == ViewTypePods {
-		// For the pods view we render the sort list and the filters for namespace, node and status.
-		// The namespaces and nodes are selected from the Kubernetes API first.
-		if listType == ListTypeSort {
-			showList = true
+	}
 
-			for index, item := range l.sortPods {
-				l.Rows = append(l.Rows, fmt.Sprintf("[%d] %s", index, item))
-			}
-		} else if listType == ListTypeFilterNamespace {
+	if viewType == ViewTypePods {
+		// For the pods view we render the filters for namespace, node and status.
+		// The namespaces and nodes are selected from the Kubernetes API first.
+		if listType == ListTypeFilterNamespace {
 			showList = true
 			l.filterNamespaces, _ = l.apiClient.GetNamespaces()
 
@@ -180,15 +272,9 @@ func (l *ListWidget) Show(viewType ViewType, listType ListType, termWidth, termH
 			}
 		}
 	} else if viewType == ViewTypeEvents {
-		// For the events view we render the sort list and the filters for namespace, node and event type.
+		// For the events view we render the filters for namespace, node, event type and reason.
 		// The namespaces and nodes are selected from the Kubernetes API first.
-		if listType == ListTypeSort {
-			showList = true
-
-			for index, item := range l.sortEvents {
-				l.Rows = append(l.Rows, fmt.Sprintf("[%d] %s", index, item))
-			}
-		} else if listType == ListTypeFilterNamespace {
+		if listType == ListTypeFilterNamespace {
 			showList = true
 			l.filterNamespaces, _ = l.apiClient.GetNamespaces()
 
@@ -208,6 +294,27 @@ func (l *ListWidget) Show(viewType ViewType, listType ListType, termWidth, termH
 			for index, eventType := range l.filterEventTypes {
 				l.Rows = append(l.Rows, fmt.Sprintf("[%d] %s", index, eventType))
 			}
+		} else if listType == ListTypeFilterReason {
+			showList = true
+			l.filterReasons, _ = l.apiClient.GetEventReasons(namespace)
+
+			for index, reason := range l.filterReasons {
+				l.Rows = append(l.Rows, fmt.Sprintf("[%d] %s", index, reason))
+			}
+		}
+	}
+
+	if listType == ListTypeAlerts {
+		// The alerts list is rendered for all views, because alerts for pods and nodes are evaluated in the corresponding views.
+		// When no alert is firing, we render a placeholder, so that the user knows that the list is empty.
+		showList = true
+
+		for index, alert := range l.alerts.Alerts() {
+			l.Rows = append(l.Rows, fmt.Sprintf("[%d] [%s] %s", index, alert.Rule.Severity, alert.Message()))
+		}
+
+		if len(l.Rows) == 0 {
+			l.Rows = append(l.Rows, "No alerts are firing")
 		}
 	}
 
@@ -221,7 +328,11 @@ func (l *ListWidget) Show(viewType ViewType, listType ListType, termWidth, termH
 
 	if showList {
 		l.SelectedRow = 0
-		l.SetRect(termWidth/2-25, termHeight/2-10, termWidth/2+25, termHeight/2+10)
+		if listType == ListTypeAlerts {
+			l.SetRect(termWidth/2-50, termHeight/2-10, termWidth/2+50, termHeight/2+10)
+		} else {
+			l.SetRect(termWidth/2-25, termHeight/2-10, termWidth/2+25, termHeight/2+10)
+		}
 	} else {
 		l.SetRect(0, 0, 0, 0)
 	}
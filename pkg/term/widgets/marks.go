@@ -0,0 +1,124 @@
+package widgets
+
+import (
+	"strings"
+)
+
+// ToggleMark marks the selected row or removes the mark from the selected row.
+// Rows are marked by their key, so that the marks are kept when the rows are reordered.
+func (t *Table) ToggleMark() {
+	key := t.RowKey(t.SelectedRow)
+	if key == "" {
+		return
+	}
+
+	if t.marked == nil {
+		t.marked = make(map[string]bool)
+	}
+
+	if t.marked[key] {
+		delete(t.marked, key)
+	} else {
+		t.marked[key] = true
+	}
+}
+
+// MarkMatches marks all rows, which match the provided query. It returns the number of rows which were marked.
+func (t *Table) MarkMatches(query string) int {
+	if t.marked == nil {
+		t.marked = make(map[string]bool)
+	}
+
+	var count int
+	for index := range t.Rows {
+		if t.Matches(index, query) {
+			t.marked[t.RowKey(index)] = true
+			count++
+		}
+	}
+
+	return count
+}
+
+// ClearMarks removes the marks from all rows.
+func (t *Table) ClearMarks() {
+	t.marked = nil
+}
+
+// Marked returns true if the row with the provided index is marked.
+func (t *Table) Marked(row int) bool {
+	return t.marked[t.RowKey(row)]
+}
+
+// MarkedKeys returns the keys of all marked rows in the order of the rows.
+func (t *Table) MarkedKeys() []string {
+	var keys []string
+	for index := range t.Rows {
+		if t.Marked(index) {
+			keys = append(keys, t.RowKey(index))
+		}
+	}
+
+	return keys
+}
+
+// MarkedRows returns the values of all marked rows in the order of the rows.
+func (t *Table) MarkedRows() [][]string {
+	var rows [][]string
+	for index, row := range t.Rows {
+		if t.Marked(index) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// pruneMarks removes the marks of all rows, which do not exist anymore, e.g. because the pod was deleted.
+func (t *Table) pruneMarks() {
+	if len(t.marked) == 0 {
+		return
+	}
+
+	keys := make(map[string]bool)
+	for index := range t.Rows {
+		keys[t.RowKey(index)] = true
+	}
+
+	for key := range t.marked {
+		if !keys[key] {
+			delete(t.marked, key)
+		}
+	}
+}
+
+// Matches returns true if one of the values of the row with the provided index contains the query. The query is case insensitive.
+func (t *Table) Matches(row int, query string) bool {
+	if query == "" || row < 0 || row >= len(t.Rows) {
+		return false
+	}
+
+	query = strings.ToLower(query)
+	for _, value := range t.Rows[row] {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelectMatch selects the next (step 1) or previous (step -1) row, which matches the query. The search continues at the other end of the table.
+// It returns false if no row matches the query.
+func (t *Table) SelectMatch(query string, step int) bool {
+	for offset := 1; offset <= len(t.Rows); offset++ {
+		index := ((t.SelectedRow+offset*step)%len(t.Rows) + len(t.Rows)) % len(t.Rows)
+		if t.Matches(index, query) {
+			t.SelectedRow = index
+			t.calcPos()
+			return true
+		}
+	}
+
+	return false
+}
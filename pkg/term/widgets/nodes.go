@@ -2,36 +2,50 @@ package widgets
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/ricoberger/kubetop/pkg/alerts"
 	"github.com/ricoberger/kubetop/pkg/api"
 	"github.com/ricoberger/kubetop/pkg/term/helpers"
 
 	ui "github.com/gizak/termui/v3"
 )
 
+// NodeColumns are all available columns for the nodes view.
+// The status and age columns are hidden by default and can be shown via the column picker or the configuration file.
+var NodeColumns = []Column{
+	{Name: "name", Header: "NAME", Index: 0, Width: 40, Flex: true, Visible: true},
+	{Name: "pods", Header: "PODS", Index: 1, Width: 20, Visible: true},
+	{Name: "cpu", Header: "CPU", Index: 2, Width: 20, Visible: true},
+	{Name: "memory", Header: "MEMORY", Index: 3, Width: 20, Visible: true},
+	{Name: "memoryMax", Header: "MEMORY MAX", Index: 4, Width: 20, Visible: true},
+	{Name: "externalIP", Header: "EXTERNAL IP", Index: 5, Width: 40, Visible: true},
+	{Name: "internalIP", Header: "INTERNAL IP", Index: 6, Width: 40, Visible: true},
+	{Name: "status", Header: "STATUS", Index: 7, Width: 12},
+	{Name: "age", Header: "AGE", Index: 8, Width: 10},
+}
+
 // NodesWidget represents the ui widget component for the nodes view.
 type NodesWidget struct {
 	*Table
 
-	apiClient *api.Client
+	apiClient api.Source
+	alerts    *alerts.Engine
 	filter    api.Filter
 	pause     bool
-	sortorder api.Sort
 }
 
 // NewNodesWidget returns a new nodes widget.
 // We create the table for the nodes widget with all the basic layout settings.
-func NewNodesWidget(apiClient *api.Client, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *NodesWidget {
+// The alerts engine is optional and used to highlight nodes for which an alert rule is firing.
+func NewNodesWidget(apiClient api.Source, alertsEngine *alerts.Engine, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *NodesWidget {
 	table := NewTable()
-	table.Header = []string{"NAME", "PODS", "CPU", "MEMORY", "MEMORY MAX", "EXTERNAL IP", "INTERNAL IP"}
-	table.UniqueCol = 0
+	table.Columns = ViewColumns(ViewTypeNodes, apiClient)
+	table.GaugeCols = map[int]bool{2: true, 3: true}
 
-	table.SetRect(0, 0, termWidth, termHeight)
+	table.Sort = sortorder
 
-	table.ColWidths = []int{helpers.MaxInt(table.Inner.Dx()-160, 40), 20, 20, 20, 20, 40, 40}
-	table.ColResizer = func() {
-		table.ColWidths = []int{helpers.MaxInt(table.Inner.Dx()-160, 40), 20, 20, 20, 20, 40, 40}
-	}
+	table.SetRect(0, 0, termWidth, termHeight)
 
 	table.Border = false
 	table.BorderStyle = ui.NewStyle(ui.ColorClear)
@@ -40,12 +54,16 @@ func NewNodesWidget(apiClient *api.Client, filter api.Filter, sortorder api.Sort
 		table,
 
 		apiClient,
+		alertsEngine,
 		filter,
 		false,
-		sortorder,
 	}
 }
 
+// Close is not used, because the view does not run anything in the background.
+func (n *NodesWidget) Close() {
+}
+
 // Filter returns the setted filter.
 func (n *NodesWidget) Filter() api.Filter {
 	return n.filter
@@ -58,7 +76,7 @@ func (n *NodesWidget) Pause() bool {
 
 // SelectedValues returns the name of the selected row.
 func (n *NodesWidget) SelectedValues() []string {
-	return n.Rows[n.SelectedRow]
+	return n.SelectedRowValues()
 }
 
 // SelectNext selects the next item in the table.
@@ -103,13 +121,17 @@ func (n *NodesWidget) SelectPageUp() {
 
 // SetSortAndFilter sets a new value for the sortorder and filter.
 func (n *NodesWidget) SetSortAndFilter(sortorder api.Sort, filter api.Filter) {
-	n.sortorder = sortorder
+	n.Sort = sortorder
 	n.filter = filter
 }
 
 // Sortorder returns the setted sortorder.
 func (n *NodesWidget) Sortorder() api.Sort {
-	return n.sortorder
+	return n.Sort
+}
+
+// ToggleFocus is not used, because the view contains only one focusable element.
+func (n *NodesWidget) ToggleFocus() {
 }
 
 // TogglePause sets toggle pause.
@@ -118,28 +140,55 @@ func (n *NodesWidget) TogglePause() {
 }
 
 // Update updates the table data of the node view.
-// Get the data for the nodes widget and add each node as seperate row to the table.
 func (n *NodesWidget) Update() error {
-	if !n.pause {
-		nodes, err := n.apiClient.GetNodesMetrics(n.sortorder)
+	return update(n.Fetch())
+}
+
+// Fetch returns the function to get the data for the nodes widget. The nodes are only shown, when the sortorder was not changed while they were fetched.
+func (n *NodesWidget) Fetch() FetchFunc {
+	if n.pause {
+		return fetchNothing
+	}
+
+	sortorder := n.Sort
+	return func() (func(), error) {
+		nodes, err := n.apiClient.GetNodesMetrics(sortorder)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		rows := make([][]string, len(nodes))
-		for i, node := range nodes {
-			rows[i] = make([]string, 7)
-			rows[i][0] = node.Name
-			rows[i][1] = fmt.Sprintf("%d", node.PodsCount)
-			rows[i][2] = fmt.Sprintf("%.2f%%", (float64(node.CPUUsed) * 100.0 / float64(node.CPUTotal)))
-			rows[i][3] = fmt.Sprintf("%.2f%%", (float64(node.MemoryUsed) * 100.0 / float64(node.MemoryTotal)))
-			rows[i][4] = helpers.FormatBytes(node.MemoryTotal)
-			rows[i][5] = node.ExternalIP
-			rows[i][6] = node.InternalIP
-		}
+		return func() {
+			if !n.pause && n.Sort == sortorder {
+				n.setNodes(nodes)
+			}
+		}, nil
+	}
+}
 
-		n.Rows = rows
+// setNodes adds each node as seperate row to the table.
+func (n *NodesWidget) setNodes(nodes []api.Node) {
+	rows := make([][]string, len(nodes))
+	keys := make([]string, len(nodes))
+	for i, node := range nodes {
+		keys[i] = node.Name
+		rows[i] = make([]string, len(NodeColumns)+len(node.Custom))
+		rows[i][0] = node.Name
+		rows[i][1] = fmt.Sprintf("%d", node.PodsCount)
+		rows[i][2] = fmt.Sprintf("%dm / %dm", node.CPUUsed, node.CPUTotal)
+		rows[i][3] = fmt.Sprintf("%s / %s", helpers.FormatBytes(node.MemoryUsed), helpers.FormatBytes(node.MemoryTotal))
+		rows[i][4] = helpers.FormatBytes(node.MemoryTotal)
+		rows[i][5] = node.ExternalIP
+		rows[i][6] = node.InternalIP
+		rows[i][7] = node.Status
+		rows[i][8] = helpers.FormatDuration(time.Now().Sub(node.CreationDate))
+		copy(rows[i][len(NodeColumns):], node.Custom)
+
+		if n.GaugeMode != GaugeModeAbsolute {
+			rows[i][2] = formatPercent(node.CPUUsed, node.CPUTotal, rows[i][2])
+			rows[i][3] = formatPercent(node.MemoryUsed, node.MemoryTotal, rows[i][3])
+		}
 	}
 
-	return nil
+	n.SetRows(rows, keys)
+	n.RowColors = alertColors(n.alerts.EvaluateNodes(nodes), keys)
 }
@@ -2,6 +2,7 @@ package widgets
 
 import (
 	"fmt"
+	"image"
 	"sort"
 	"strings"
 	"time"
@@ -20,19 +21,33 @@ type PodDetailsWidget struct {
 	podDetails1 *w.Paragraph
 	podDetails2 *w.Paragraph
 	containers  *Table
+	diagnostics *w.Paragraph
+	events      *Table
 	logs        *w.Paragraph
 
-	apiClient *api.Client
-	filter    api.Filter
-	name      string
-	namespace string
-	pause     bool
-	sortorder api.Sort
+	apiClient     api.Source
+	eventsFocused bool
+	filter        api.Filter
+	memoryHistory map[string][]memorySample
+	name          string
+	namespace     string
+	pause         bool
+	sortorder     api.Sort
 }
 
+// memorySample is the memory usage of a container at a specific time.
+// The samples are used to show the peak memory usage of a container in the diagnostics panel.
+type memorySample struct {
+	memory    int64
+	timestamp time.Time
+}
+
+// memoryHistoryRetention is the time how long the memory samples of a container are kept.
+const memoryHistoryRetention = 5 * time.Minute
+
 // NewPodDetailsWidget returns a new pods widget.
 // We create the table for the pods widget with all the basic layout settings.
-func NewPodDetailsWidget(name, namespace string, apiClient *api.Client, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *PodDetailsWidget {
+func NewPodDetailsWidget(name, namespace string, apiClient api.Source, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *PodDetailsWidget {
 	block := ui.NewBlock()
 	block.SetRect(0, 0, termWidth, termHeight)
 
@@ -40,13 +55,33 @@ func NewPodDetailsWidget(name, namespace string, apiClient *api.Client, filter a
 	podDetails2 := w.NewParagraph()
 
 	containers := NewTable()
-	containers.Header = []string{"NAME", "RESTARTS", "STATUS", "CPU", "CPU MIN", "CPU MAX", "MEMORY", "MEMORY MIN", "MEMORY MAX"}
+	containers.Header = []string{"NAME", "RESTARTS", "STATUS", "LAST TERMINATION", "CPU", "CPU MIN", "CPU MAX", "MEMORY", "MEMORY MIN", "MEMORY MAX"}
 	containers.UniqueCol = 0
 	containers.Border = false
 	containers.BorderStyle = ui.NewStyle(ui.ColorClear)
-	containers.ColWidths = []int{helpers.MaxInt(containers.Inner.Dx()-180, 40), 20, 40, 20, 20, 20, 20, 20, 20}
+	containers.ColWidths = []int{helpers.MaxInt(containers.Inner.Dx()-160, 30), 10, 30, 30, 15, 15, 15, 15, 15, 15}
 	containers.ColResizer = func() {
-		containers.ColWidths = []int{helpers.MaxInt(containers.Inner.Dx()-180, 40), 20, 40, 20, 20, 20, 20, 20, 20}
+		containers.ColWidths = []int{helpers.MaxInt(containers.Inner.Dx()-160, 30), 10, 30, 30, 15, 15, 15, 15, 15, 15}
+	}
+
+	diagnostics := w.NewParagraph()
+	diagnostics.Border = true
+	diagnostics.Title = "Diagnostics"
+	diagnostics.TitleStyle = ui.NewStyle(ui.ColorClear)
+	diagnostics.BorderStyle = ui.NewStyle(ui.ColorYellow)
+	diagnostics.TextStyle = ui.NewStyle(ui.ColorClear)
+	diagnostics.WrapText = true
+
+	events := NewTable()
+	events.Header = []string{"LAST SEEN", "TYPE", "REASON", "COUNT", "SOURCE", "FIRST SEEN", "MESSAGE"}
+	events.Title = "Events"
+	events.TitleStyle = ui.NewStyle(ui.ColorClear)
+	events.BorderStyle = ui.NewStyle(ui.ColorClear)
+	events.ShowCursor = false
+	events.ShowLocation = true
+	events.ColWidths = []int{10, 10, 25, 10, 25, 10, helpers.MaxInt(events.Inner.Dx()-90, 40)}
+	events.ColResizer = func() {
+		events.ColWidths = []int{10, 10, 25, 10, 25, 10, helpers.MaxInt(events.Inner.Dx()-90, 40)}
 	}
 
 	logs := w.NewParagraph()
@@ -62,10 +97,14 @@ func NewPodDetailsWidget(name, namespace string, apiClient *api.Client, filter a
 		podDetails1,
 		podDetails2,
 		containers,
+		diagnostics,
+		events,
 		logs,
 
 		apiClient,
+		false,
 		filter,
+		make(map[string][]memorySample),
 		name,
 		namespace,
 		false,
@@ -73,6 +112,10 @@ func NewPodDetailsWidget(name, namespace string, apiClient *api.Client, filter a
 	}
 }
 
+// Close is not used, because the view does not run anything in the background.
+func (p *PodDetailsWidget) Close() {
+}
+
 // Filter returns the setted filter.
 func (p *PodDetailsWidget) Filter() api.Filter {
 	return p.filter
@@ -83,43 +126,83 @@ func (p *PodDetailsWidget) Pause() bool {
 	return p.pause
 }
 
+// Pod returns the name and the namespace of the shown pod.
+func (p *PodDetailsWidget) Pod() (string, string) {
+	return p.name, p.namespace
+}
+
+// SelectedContainer returns the name of the selected container. If the containers were not loaded yet, an empty string is returned.
+func (p *PodDetailsWidget) SelectedContainer() string {
+	if values := p.containers.SelectedRowValues(); values != nil {
+		return values[0]
+	}
+
+	return ""
+}
+
 // SelectedValues returns the name of the selected pod.
 func (p *PodDetailsWidget) SelectedValues() []string {
 	return []string{}
 }
 
-// SelectNext selects the next log line.
+// SelectNext selects the next container or the next event, when the events table is focused.
 func (p *PodDetailsWidget) SelectNext() {
-	p.containers.ScrollDown()
+	if p.eventsFocused {
+		p.events.ScrollDown()
+	} else {
+		p.containers.ScrollDown()
+	}
 }
 
-// SelectPrev selects the previous log line.
+// SelectPrev selects the previous container or the previous event, when the events table is focused.
 func (p *PodDetailsWidget) SelectPrev() {
-	p.containers.ScrollUp()
+	if p.eventsFocused {
+		p.events.ScrollUp()
+	} else {
+		p.containers.ScrollUp()
+	}
 }
 
-// SelectTop selects the top item in the table.
+// SelectTop selects the first event, when the events table is focused.
 func (p *PodDetailsWidget) SelectTop() {
+	if p.eventsFocused {
+		p.events.ScrollTop()
+	}
 }
 
-// SelectBottom selects the bottom item in the table.
+// SelectBottom selects the last event, when the events table is focused.
 func (p *PodDetailsWidget) SelectBottom() {
+	if p.eventsFocused {
+		p.events.ScrollBottom()
+	}
 }
 
-// SelectHalfPageDown selects the item a half page down.
+// SelectHalfPageDown selects the event a half page down, when the events table is focused.
 func (p *PodDetailsWidget) SelectHalfPageDown() {
+	if p.eventsFocused {
+		p.events.ScrollHalfPageDown()
+	}
 }
 
-// SelectHalfPageUp selects the item a half page up.
+// SelectHalfPageUp selects the event a half page up, when the events table is focused.
 func (p *PodDetailsWidget) SelectHalfPageUp() {
+	if p.eventsFocused {
+		p.events.ScrollHalfPageUp()
+	}
 }
 
-// SelectPageDown selects the item on the next page.
+// SelectPageDown selects the event on the next page, when the events table is focused.
 func (p *PodDetailsWidget) SelectPageDown() {
+	if p.eventsFocused {
+		p.events.ScrollPageDown()
+	}
 }
 
-// SelectPageUp selects the item on the previous page.
+// SelectPageUp selects the event on the previous page, when the events table is focused.
 func (p *PodDetailsWidget) SelectPageUp() {
+	if p.eventsFocused {
+		p.events.ScrollPageUp()
+	}
 }
 
 // SetSortAndFilter sets a new value for the sortorder and filter.
@@ -133,6 +216,35 @@ func (p *PodDetailsWidget) Sortorder() api.Sort {
 	return p.sortorder
 }
 
+// ToggleFocus switches the focus between the containers and the events table.
+func (p *PodDetailsWidget) ToggleFocus() {
+	p.eventsFocused = !p.eventsFocused
+	p.containers.ShowCursor = !p.eventsFocused
+	p.events.ShowCursor = p.eventsFocused
+}
+
+// HandleClick selects the clicked container or event.
+// The clicked table is focused, so that the keys can be used for this table afterwards.
+func (p *PodDetailsWidget) HandleClick(x, y int) bool {
+	if image.Pt(x, y).In(p.containers.Rectangle) {
+		if p.eventsFocused {
+			p.ToggleFocus()
+		}
+
+		return p.containers.HandleClick(x, y)
+	}
+
+	if image.Pt(x, y).In(p.events.Rectangle) {
+		if !p.eventsFocused {
+			p.ToggleFocus()
+		}
+
+		return p.events.HandleClick(x, y)
+	}
+
+	return false
+}
+
 // TogglePause sets toggle pause.
 func (p *PodDetailsWidget) TogglePause() {
 	p.pause = !p.pause
@@ -140,153 +252,216 @@ func (p *PodDetailsWidget) TogglePause() {
 
 // Update updates the data for the details view of a pod.
 func (p *PodDetailsWidget) Update() error {
-	if !p.pause {
-		pod, err := p.apiClient.GetPod(p.name, p.namespace, p.containers.SelectedRow)
-		if err != nil {
-			return err
-		}
+	return update(p.Fetch())
+}
 
-		// Render the first section of pod details: name, namespace, node, controlled by
-		// First we create our string for the controlled by field.
-		// Then we create our string for the events.
-		// We render a maximum amount of five events, sorted by the timestamp (timestamp is the time when the event was fired the last time).
-		var controlledBy string
-		for index, controller := range pod.ControlledBy {
-			if index == 0 {
-				controlledBy = controlledBy + controller
-			} else {
-				controlledBy = controlledBy + "\n               " + controller
-			}
-		}
+// Fetch returns the function to get the details of the pod. The details are only shown, when no other container was selected while they were fetched.
+func (p *PodDetailsWidget) Fetch() FetchFunc {
+	if p.pause {
+		return fetchNothing
+	}
 
-		sort.SliceStable(pod.Events, func(i, j int) bool {
-			return pod.Events[i].Timestamp > pod.Events[j].Timestamp
-		})
+	selectedContainer := p.containers.SelectedRow
+	return func() (func(), error) {
+		pod, err := p.apiClient.GetPod(p.name, p.namespace, selectedContainer)
+		if err != nil {
+			return nil, err
+		}
 
-		var events string
-		for index, event := range pod.Events {
-			if index == 5 {
-				break
+		return func() {
+			if !p.pause && p.containers.SelectedRow == selectedContainer {
+				p.setPod(pod)
 			}
+		}, nil
+	}
+}
 
-			if index == 0 {
-				events = events + time.Unix(event.Timestamp, 0).Format("Mon, 02 Jan 2006 15:04:05 -0700") + ": " + event.Message
-			} else {
-				events = events + "\n               " + time.Unix(event.Timestamp, 0).Format("Mon, 02 Jan 2006 15:04:05 -0700") + ": " + event.Message
-			}
+// setPod renders the details of the pod and calculates the layout of the sections.
+func (p *PodDetailsWidget) setPod(pod *api.Pod) {
+	// Render the first section of pod details: name, namespace, node, controlled by
+	// First we create our string for the controlled by field.
+	var controlledBy string
+	for index, controller := range pod.ControlledBy {
+		if index == 0 {
+			controlledBy = controlledBy + controller
+		} else {
+			controlledBy = controlledBy + "\n               " + controller
 		}
+	}
 
-		p.podDetails1.Border = false
-		p.podDetails1.Text = fmt.Sprintf(`
+	p.podDetails1.Border = false
+	p.podDetails1.Text = fmt.Sprintf(`
 			Name:          %s
 			Namespace:     %s
 			Node:          %s
 			Status:        %s
 			Start Time:    %s
 			IP:            %s
-			Controlled By: %s
-			Events:        %s`, pod.Name, pod.Namespace, pod.NodeName, pod.Status, pod.CreationDate.Format("Mon, 02 Jan 2006 15:04:05 -0700"), pod.IP, controlledBy, events)
-
-		// Render the second section of pod details: labels, annotations
-		// First we sort the labels by there key and then we create the string for rendering.
-		labels := make([]string, 0, len(pod.Labels))
-		for label := range pod.Labels {
-			labels = append(labels, label)
-		}
-		sort.Strings(labels)
-
-		var labelsStr string
-		var labelsIndex int
-		for _, key := range labels {
-			if labelsIndex == 0 {
-				labelsStr = labelsStr + key + "=" + pod.Labels[key]
-			} else {
-				labelsStr = labelsStr + "\n             " + key + "=" + pod.Labels[key]
-			}
-			labelsIndex++
-		}
+			Controlled By: %s`, pod.Name, pod.Namespace, pod.NodeName, pod.Status, pod.CreationDate.Format("Mon, 02 Jan 2006 15:04:05 -0700"), pod.IP, controlledBy)
 
-		annotations := make([]string, 0, len(pod.Annotations))
-		for annotation := range pod.Annotations {
-			annotations = append(annotations, annotation)
+	// Render the second section of pod details: labels, annotations
+	// First we sort the labels by there key and then we create the string for rendering.
+	labels := make([]string, 0, len(pod.Labels))
+	for label := range pod.Labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var labelsStr string
+	var labelsIndex int
+	for _, key := range labels {
+		if labelsIndex == 0 {
+			labelsStr = labelsStr + key + "=" + pod.Labels[key]
+		} else {
+			labelsStr = labelsStr + "\n             " + key + "=" + pod.Labels[key]
 		}
-		sort.Strings(annotations)
+		labelsIndex++
+	}
 
-		var annotationsStr string
-		var annotationsIndex int
-		for _, key := range annotations {
-			if annotationsIndex == 0 {
-				annotationsStr = annotationsStr + key + "=" + pod.Annotations[key]
-			} else {
-				annotationsStr = annotationsStr + "\n             " + key + "=" + pod.Annotations[key]
-			}
-			annotationsIndex++
+	annotations := make([]string, 0, len(pod.Annotations))
+	for annotation := range pod.Annotations {
+		annotations = append(annotations, annotation)
+	}
+	sort.Strings(annotations)
+
+	var annotationsStr string
+	var annotationsIndex int
+	for _, key := range annotations {
+		if annotationsIndex == 0 {
+			annotationsStr = annotationsStr + key + "=" + pod.Annotations[key]
+		} else {
+			annotationsStr = annotationsStr + "\n             " + key + "=" + pod.Annotations[key]
 		}
+		annotationsIndex++
+	}
 
-		p.podDetails2.Border = false
-		p.podDetails2.Text = fmt.Sprintf(`
+	p.podDetails2.Border = false
+	p.podDetails2.Text = fmt.Sprintf(`
 			Labels:      %s
 			Annotations: %s`, labelsStr, annotationsStr)
 
-		// Render table with the containers.
-		rows := make([][]string, len(pod.Containers))
-		for i, container := range pod.Containers {
-			rows[i] = make([]string, 9)
-			rows[i][0] = container.Name
-			rows[i][1] = fmt.Sprintf("%d", container.Restarts)
-			rows[i][2] = container.Status
-			rows[i][3] = fmt.Sprintf("%dm", container.CPU)
-			rows[i][4] = helpers.RenderCPUMax(container.CPUMin, 1, 1)
-			rows[i][5] = helpers.RenderCPUMax(container.CPUMax, 1, 1)
-			rows[i][6] = helpers.FormatBytes(container.Memory)
-			rows[i][7] = helpers.RenderMemoryMax(container.MemoryMin, 1, 1)
-			rows[i][8] = helpers.RenderMemoryMax(container.MemoryMax, 1, 1)
+	// Render table with the containers.
+	// Containers which were terminated before are highlighted, OOMKilled containers are highlighted in red.
+	// We also record the memory usage of each container, so that we can show the recent peak memory usage in the diagnostics panel.
+	rows := make([][]string, len(pod.Containers))
+	containerColors := make(map[int]ui.Color)
+	for i, container := range pod.Containers {
+		rows[i] = make([]string, 10)
+		rows[i][0] = container.Name
+		rows[i][1] = fmt.Sprintf("%d", container.Restarts)
+		rows[i][2] = container.Status
+		rows[i][3] = formatTermination(container.LastTerminationState)
+		rows[i][4] = fmt.Sprintf("%dm", container.CPU)
+		rows[i][5] = helpers.RenderCPUMax(container.CPUMin, 1, 1)
+		rows[i][6] = helpers.RenderCPUMax(container.CPUMax, 1, 1)
+		rows[i][7] = helpers.FormatBytes(container.Memory)
+		rows[i][8] = helpers.RenderMemoryMax(container.MemoryMin, 1, 1)
+		rows[i][9] = helpers.RenderMemoryMax(container.MemoryMax, 1, 1)
+
+		if container.LastTerminationState != nil {
+			if container.LastTerminationState.Reason == "OOMKilled" {
+				containerColors[i] = ui.ColorRed
+			} else {
+				containerColors[i] = ui.ColorYellow
+			}
 		}
 
-		p.containers.Rows = rows
+		p.recordMemory(container)
+	}
 
-		// Render log lines.
-		// First reverse the order of the log lines, so the newest one is on top.
-		// Then set the loglines as rows for the logs list.
-		for i := len(pod.LogLines)/2 - 1; i >= 0; i-- {
-			opp := len(pod.LogLines) - 1 - i
-			pod.LogLines[i], pod.LogLines[opp] = pod.LogLines[opp], pod.LogLines[i]
+	p.containers.Rows = rows
+	p.containers.RowColors = containerColors
+
+	// Render the diagnostics panel for the selected container.
+	// The panel is only shown, when the selected container was terminated before or is crash looping.
+	var showDiagnostics bool
+	if p.containers.SelectedRow >= 0 && p.containers.SelectedRow < len(pod.Containers) {
+		container := pod.Containers[p.containers.SelectedRow]
+		if container.LastTerminationState != nil || container.Status == "CrashLoopBackOff" {
+			p.diagnostics.Text = p.diagnose(container)
+			showDiagnostics = true
 		}
+	}
 
-		// We need to check if the first line is empty, because we reverse the order of the log lines.
-		// In the API call we split the log lines at a new line character and so the last log line is always empty.
-		var firstLogLine int
-		if len(pod.LogLines) > 0 && pod.LogLines[0] == "" {
-			firstLogLine = 1
+	// Render table with all events of the pod.
+	// The events are sorted by the timestamp (timestamp is the time when the event was fired the last time), so that the newest event is on top.
+	// Warning events are highlighted, so that they can be found faster in a long list of events.
+	sort.SliceStable(pod.Events, func(i, j int) bool {
+		return pod.Events[i].Timestamp > pod.Events[j].Timestamp
+	})
+
+	eventRows := make([][]string, len(pod.Events))
+	eventKeys := make([]string, len(pod.Events))
+	eventColors := make(map[int]ui.Color)
+	for i, event := range pod.Events {
+		eventKeys[i] = event.UID
+		eventRows[i] = make([]string, 7)
+		eventRows[i][0] = helpers.FormatDuration(time.Now().Sub(event.LastTimestamp))
+		eventRows[i][1] = event.Type
+		eventRows[i][2] = event.Reason
+		eventRows[i][3] = fmt.Sprintf("%d", event.Count)
+		eventRows[i][4] = event.Source
+		eventRows[i][5] = helpers.FormatDuration(time.Now().Sub(event.FirstTimestamp))
+		eventRows[i][6] = event.Message
+
+		if event.Type == "Warning" {
+			eventColors[i] = ui.ColorYellow
 		}
+	}
 
-		p.logs.Text = strings.Join(pod.LogLines[firstLogLine:len(pod.LogLines)], "\n")
-
-		// Bring it all together and calculate the position for podDetails1, podDetails2, containers and logs.
-		// Caculate the position of the containers table based on the height of podDetails1 and podDetails2.
-		// Use this value to set the positions of all elements.
-		termWidth, termHeight := ui.TerminalDimensions()
-		minHeight := 8
-		detailsHeight := 11
-		podDetails1Height := 8 + len(pod.ControlledBy) + helpers.MinInt(len(pod.Events), 5)
-		if len(pod.ControlledBy) > 0 {
-			podDetails1Height--
-		}
-		if len(pod.Events) > 0 {
-			podDetails1Height--
-		}
-		podDetails2Height := len(labels) + len(annotations)
-		if helpers.MaxInt(podDetails1Height, podDetails2Height) >= minHeight {
-			detailsHeight = detailsHeight + helpers.MaxInt(podDetails1Height, podDetails2Height) - minHeight
-		}
+	p.events.SetRows(eventRows, eventKeys)
+	p.events.RowColors = eventColors
+
+	// Render log lines.
+	// First reverse the order of the log lines, so the newest one is on top.
+	// Then set the loglines as rows for the logs list.
+	for i := len(pod.LogLines)/2 - 1; i >= 0; i-- {
+		opp := len(pod.LogLines) - 1 - i
+		pod.LogLines[i], pod.LogLines[opp] = pod.LogLines[opp], pod.LogLines[i]
+	}
+
+	// We need to check if the first line is empty, because we reverse the order of the log lines.
+	// In the API call we split the log lines at a new line character and so the last log line is always empty.
+	var firstLogLine int
+	if len(pod.LogLines) > 0 && pod.LogLines[0] == "" {
+		firstLogLine = 1
+	}
+
+	p.logs.Text = strings.Join(pod.LogLines[firstLogLine:len(pod.LogLines)], "\n")
+
+	// Bring it all together and calculate the position for podDetails1, podDetails2, containers, events and logs.
+	// Caculate the position of the containers table based on the height of podDetails1 and podDetails2.
+	// The events table shows a maximum of eight events at once, all other events can be viewed by scrolling through the table.
+	// Use this value to set the positions of all elements.
+	// The elements are positioned relative to the rectangle of the view, so that the view can also be rendered below the breadcrumbs or in a split pane.
+	minX, minY, maxX, maxY := p.Min.X, p.Min.Y, p.Max.X, p.Max.Y
+	midX := minX + (maxX-minX)/2
+	minHeight := 8
+	detailsHeight := 11
+	podDetails1Height := 7 + len(pod.ControlledBy)
+	if len(pod.ControlledBy) > 0 {
+		podDetails1Height--
+	}
+	podDetails2Height := len(labels) + len(annotations)
+	if helpers.MaxInt(podDetails1Height, podDetails2Height) >= minHeight {
+		detailsHeight = detailsHeight + helpers.MaxInt(podDetails1Height, podDetails2Height) - minHeight
+	}
+
+	containersHeight := 5 + len(p.containers.Rows)
+	eventsHeight := 3 + helpers.MaxInt(helpers.MinInt(len(p.events.Rows), 8), 1)
 
-		p.podDetails1.SetRect(0, 0, termWidth/2, detailsHeight)
-		p.podDetails2.SetRect(termWidth/2, 0, termWidth, detailsHeight)
-		p.containers.SetRect(0, detailsHeight, termWidth, detailsHeight+5+(len(p.containers.Rows)))
-		p.logs.SetRect(0, detailsHeight+5+(len(p.containers.Rows)), termWidth, termHeight-1)
+	// The text of the diagnostics panel is wrapped, so the height is calculated from the number of wrapped lines for the inner width of the panel.
+	var diagnosticsHeight int
+	if showDiagnostics {
+		diagnosticsHeight = 2 + wrappedLines(p.diagnostics.Text, p.diagnostics.TextStyle, maxX-minX-2)
 	}
 
-	return nil
+	p.podDetails1.SetRect(minX, minY, midX, minY+detailsHeight)
+	p.podDetails2.SetRect(midX, minY, maxX, minY+detailsHeight)
+	p.containers.SetRect(minX, minY+detailsHeight, maxX, minY+detailsHeight+containersHeight)
+	p.diagnostics.SetRect(minX, minY+detailsHeight+containersHeight, maxX, minY+detailsHeight+containersHeight+diagnosticsHeight)
+	p.events.SetRect(minX, minY+detailsHeight+containersHeight+diagnosticsHeight, maxX, minY+detailsHeight+containersHeight+diagnosticsHeight+eventsHeight)
+	p.logs.SetRect(minX, minY+detailsHeight+containersHeight+diagnosticsHeight+eventsHeight, maxX, maxY-1)
 }
 
 // Draw renders our statusbar.
@@ -294,5 +469,130 @@ func (p *PodDetailsWidget) Draw(buf *ui.Buffer) {
 	p.podDetails1.Draw(buf)
 	p.podDetails2.Draw(buf)
 	p.containers.Draw(buf)
+	if p.diagnostics.Dy() > 0 {
+		p.diagnostics.Draw(buf)
+	}
+	p.events.Draw(buf)
 	p.logs.Draw(buf)
 }
+
+// recordMemory adds the current memory usage of the container to the memory history.
+// Samples which are older than the retention are removed from the history.
+func (p *PodDetailsWidget) recordMemory(container api.Container) {
+	now := time.Now()
+	samples := append(p.memoryHistory[container.Name], memorySample{container.Memory, now})
+
+	for len(samples) > 0 && now.Sub(samples[0].timestamp) > memoryHistoryRetention {
+		samples = samples[1:]
+	}
+
+	p.memoryHistory[container.Name] = samples
+}
+
+// peakMemory returns the highest memory usage of the container from the memory history.
+func (p *PodDetailsWidget) peakMemory(name string) int64 {
+	var peak int64
+	for _, sample := range p.memoryHistory[name] {
+		if sample.memory > peak {
+			peak = sample.memory
+		}
+	}
+
+	return peak
+}
+
+// wrappedLines returns the number of lines of the text, when it is wrapped like the text of a paragraph with the provided width.
+func wrappedLines(text string, style ui.Style, width int) int {
+	if width <= 0 {
+		return len(strings.Split(text, "\n"))
+	}
+
+	return len(ui.SplitCells(ui.WrapCells(ui.ParseStyles(text, style), uint(width)), '\n'))
+}
+
+// diagnose returns the text for the diagnostics panel of a container.
+// We correlate the last termination of the container with the memory limit and the recent memory usage, to give a hint why the container was terminated.
+func (p *PodDetailsWidget) diagnose(container api.Container) string {
+	var lines []string
+	var hints []string
+
+	lines = append(lines, fmt.Sprintf("Container:        %s (%s, %d restarts)", container.Name, container.Status, container.Restarts))
+
+	termination := container.LastTerminationState
+	if termination != nil {
+		lines = append(lines, fmt.Sprintf("Last Termination: %s, exit code %d%s", termination.Reason, termination.ExitCode, formatSignal(termination.Signal, termination.ExitCode)))
+		lines = append(lines, fmt.Sprintf("Last Run:         %s - %s (ran for %s, terminated %s ago)", termination.StartedAt.Format("15:04:05"), termination.FinishedAt.Format("15:04:05"), helpers.FormatDuration(termination.FinishedAt.Sub(termination.StartedAt)), helpers.FormatDuration(time.Now().Sub(termination.FinishedAt))))
+
+		if termination.Message != "" {
+			lines = append(lines, fmt.Sprintf("Message:          %s", termination.Message))
+		}
+	}
+
+	peak := p.peakMemory(container.Name)
+	if container.MemoryMax > 0 {
+		lines = append(lines, fmt.Sprintf("Memory:           %s of %s limit (%.2f%%), recent peak %s (%.2f%%)", helpers.FormatBytes(container.Memory), helpers.FormatBytes(container.MemoryMax), float64(container.Memory)*100.0/float64(container.MemoryMax), helpers.FormatBytes(peak), float64(peak)*100.0/float64(container.MemoryMax)))
+	} else {
+		lines = append(lines, fmt.Sprintf("Memory:           %s, recent peak %s, no limit set", helpers.FormatBytes(container.Memory), helpers.FormatBytes(peak)))
+	}
+
+	if termination != nil {
+		switch {
+		case termination.Reason == "OOMKilled" && container.MemoryMax > 0:
+			hints = append(hints, fmt.Sprintf("The container exceeded its memory limit of %s and was killed by the kernel. Increase the memory limit or reduce the memory usage of the application.", helpers.FormatBytes(container.MemoryMax)))
+		case termination.Reason == "OOMKilled":
+			hints = append(hints, "The container was killed by the kernel, because the node ran out of memory. Set a memory limit for the container to protect the node.")
+		case termination.ExitCode == 137:
+			hints = append(hints, "The container was killed with SIGKILL, e.g. by a failing liveness probe or because it did not stop within the termination grace period.")
+		case termination.ExitCode == 143:
+			hints = append(hints, "The container was stopped with SIGTERM, e.g. during a rollout or a failing liveness probe.")
+		case termination.ExitCode != 0:
+			hints = append(hints, fmt.Sprintf("The application exited with exit code %d. Check the logs of the previous run with 'kubectl logs --previous'.", termination.ExitCode))
+		}
+	}
+
+	if container.MemoryMax > 0 && peak*100 >= container.MemoryMax*90 && (termination == nil || termination.Reason != "OOMKilled") {
+		hints = append(hints, "The memory usage is close to the memory limit, the container may be OOMKilled soon.")
+	}
+
+	if container.Status == "CrashLoopBackOff" {
+		hints = append(hints, "The container is crash looping and is restarted with an increasing back-off delay.")
+	}
+
+	for index, hint := range hints {
+		if index == 0 {
+			lines = append(lines, "Diagnosis:        "+hint)
+		} else {
+			lines = append(lines, "                  "+hint)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatTermination returns the last termination state of a container for the containers table.
+func formatTermination(termination *api.ContainerTermination) string {
+	if termination == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s (%d) %s ago", termination.Reason, termination.ExitCode, helpers.FormatDuration(time.Now().Sub(termination.FinishedAt)))
+}
+
+// formatSignal returns the signal which terminated the container.
+// If the signal is not set, we derive it from the exit code, because exit codes greater than 128 mean that the process was terminated by the signal 'exit code - 128'.
+func formatSignal(signal, exitCode int32) string {
+	if signal == 0 && exitCode > 128 {
+		signal = exitCode - 128
+	}
+
+	switch signal {
+	case 0:
+		return ""
+	case 9:
+		return ", signal 9 (SIGKILL)"
+	case 15:
+		return ", signal 15 (SIGTERM)"
+	default:
+		return fmt.Sprintf(", signal %d", signal)
+	}
+}
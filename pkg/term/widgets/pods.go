@@ -2,37 +2,56 @@ package widgets
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ricoberger/kubetop/pkg/alerts"
 	"github.com/ricoberger/kubetop/pkg/api"
 	"github.com/ricoberger/kubetop/pkg/term/helpers"
 
 	ui "github.com/gizak/termui/v3"
 )
 
+// PodColumns are all available columns for the pods view.
+// The node, qos class and owner columns are hidden by default and can be shown via the column picker or the configuration file.
+var PodColumns = []Column{
+	{Name: "namespace", Header: "NAMESPACE", Index: 0, Width: 20, Visible: true},
+	{Name: "name", Header: "POD", Index: 1, Width: 40, Flex: true, Visible: true},
+	{Name: "ready", Header: "READY", Index: 2, Width: 10, Visible: true},
+	{Name: "status", Header: "STATUS", Index: 3, Width: 20, Visible: true},
+	{Name: "restarts", Header: "RESTARTS", Index: 4, Width: 10, Visible: true},
+	{Name: "cpu", Header: "CPU", Index: 5, Width: 15, Visible: true},
+	{Name: "cpuMax", Header: "CPU MAX", Index: 6, Width: 15, Visible: true},
+	{Name: "memory", Header: "MEMORY", Index: 7, Width: 15, Visible: true},
+	{Name: "memoryMax", Header: "MEMORY MAX", Index: 8, Width: 15, Visible: true},
+	{Name: "ip", Header: "IP", Index: 9, Width: 20, Visible: true},
+	{Name: "age", Header: "AGE", Index: 10, Width: 10, Visible: true},
+	{Name: "node", Header: "NODE", Index: 11, Width: 30},
+	{Name: "qos", Header: "QOS", Index: 12, Width: 12},
+	{Name: "owner", Header: "OWNER", Index: 13, Width: 40},
+}
+
 // PodsWidget represents the ui widget component for the pods view.
 type PodsWidget struct {
 	*Table
 
-	apiClient *api.Client
+	apiClient api.Source
+	alerts    *alerts.Engine
 	filter    api.Filter
 	pause     bool
-	sortorder api.Sort
 }
 
 // NewPodsWidget returns a new pods widget.
 // We create the table for the pods widget with all the basic layout settings.
-func NewPodsWidget(apiClient *api.Client, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *PodsWidget {
+// The alerts engine is optional and used to highlight pods for which an alert rule is firing.
+func NewPodsWidget(apiClient api.Source, alertsEngine *alerts.Engine, filter api.Filter, sortorder api.Sort, termWidth, termHeight int) *PodsWidget {
 	table := NewTable()
-	table.Header = []string{"NAMESPACE", "POD", "READY", "STATUS", "RESTARTS", "CPU", "CPU MAX", "MEMORY", "MEMORY MAX", "IP", "AGE"}
-	table.UniqueCol = 1
+	table.Columns = ViewColumns(ViewTypePods, apiClient)
+	table.GaugeCols = map[int]bool{5: true, 7: true}
 
-	table.SetRect(0, 0, termWidth, termHeight)
+	table.Sort = sortorder
 
-	table.ColWidths = []int{20, helpers.MaxInt(table.Inner.Dx()-150, 40), 10, 20, 10, 15, 15, 15, 15, 20, 10}
-	table.ColResizer = func() {
-		table.ColWidths = []int{20, helpers.MaxInt(table.Inner.Dx()-150, 40), 10, 20, 10, 15, 15, 15, 15, 20, 10}
-	}
+	table.SetRect(0, 0, termWidth, termHeight)
 
 	table.Border = false
 	table.BorderStyle = ui.NewStyle(ui.ColorClear)
@@ -41,12 +60,16 @@ func NewPodsWidget(apiClient *api.Client, filter api.Filter, sortorder api.Sort,
 		table,
 
 		apiClient,
+		alertsEngine,
 		filter,
 		false,
-		sortorder,
 	}
 }
 
+// Close is not used, because the view does not run anything in the background.
+func (p *PodsWidget) Close() {
+}
+
 // Filter returns the setted filter.
 func (p *PodsWidget) Filter() api.Filter {
 	return p.filter
@@ -57,9 +80,9 @@ func (p *PodsWidget) Pause() bool {
 	return p.pause
 }
 
-// SelectedValues returns the name of the selected pod.
+// SelectedValues returns the values of the selected pod.
 func (p *PodsWidget) SelectedValues() []string {
-	return p.Rows[p.SelectedRow]
+	return p.SelectedRowValues()
 }
 
 // SelectNext selects the next item in the table.
@@ -104,13 +127,17 @@ func (p *PodsWidget) SelectPageUp() {
 
 // SetSortAndFilter sets a new value for the sortorder and filter.
 func (p *PodsWidget) SetSortAndFilter(sortorder api.Sort, filter api.Filter) {
-	p.sortorder = sortorder
+	p.Sort = sortorder
 	p.filter = filter
 }
 
 // Sortorder returns the setted sortorder.
 func (p *PodsWidget) Sortorder() api.Sort {
-	return p.sortorder
+	return p.Sort
+}
+
+// ToggleFocus is not used, because the view contains only one focusable element.
+func (p *PodsWidget) ToggleFocus() {
 }
 
 // TogglePause sets toggle pause.
@@ -119,32 +146,61 @@ func (p *PodsWidget) TogglePause() {
 }
 
 // Update updates the table data of the pod view.
-// Get the data for the pods widget and add each pod as seperate row to the table.
 func (p *PodsWidget) Update() error {
-	if !p.pause {
-		pods, err := p.apiClient.GetPodsMetrics(p.filter, p.sortorder)
+	return update(p.Fetch())
+}
+
+// Fetch returns the function to get the data for the pods widget. The pods are only shown, when the filter and the sortorder were not changed while they were fetched.
+func (p *PodsWidget) Fetch() FetchFunc {
+	if p.pause {
+		return fetchNothing
+	}
+
+	filter, sortorder := p.filter, p.Sort
+	return func() (func(), error) {
+		pods, err := p.apiClient.GetPodsMetrics(filter, sortorder)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		rows := make([][]string, len(pods))
-		for i, pod := range pods {
-			rows[i] = make([]string, 11)
-			rows[i][0] = pod.Namespace
-			rows[i][1] = pod.Name
-			rows[i][2] = fmt.Sprintf("%d/%d", pod.ContainersReady, pod.ContainersCount)
-			rows[i][3] = pod.Status
-			rows[i][4] = fmt.Sprintf("%d", pod.Restarts)
-			rows[i][5] = fmt.Sprintf("%dm", pod.CPU)
-			rows[i][6] = helpers.RenderCPUMax(pod.CPUMax, pod.CPUMaxContainerCount, int64(pod.ContainersCount))
-			rows[i][7] = helpers.FormatBytes(pod.Memory)
-			rows[i][8] = helpers.RenderMemoryMax(pod.MemoryMax, pod.MemoryMaxContainerCount, int64(pod.ContainersCount))
-			rows[i][9] = pod.IP
-			rows[i][10] = helpers.FormatDuration(time.Now().Sub(pod.CreationDate))
-		}
+		return func() {
+			if !p.pause && p.filter == filter && p.Sort == sortorder {
+				p.setPods(pods)
+			}
+		}, nil
+	}
+}
 
-		p.Rows = rows
+// setPods adds each pod as seperate row to the table.
+// The cpu and memory usage is shown as percentage of the limit, when the gauge mode is not absolute and a limit is set.
+func (p *PodsWidget) setPods(pods []api.Pod) {
+	rows := make([][]string, len(pods))
+	keys := make([]string, len(pods))
+	for i, pod := range pods {
+		keys[i] = pod.Namespace + "/" + pod.Name
+		rows[i] = make([]string, len(PodColumns)+len(pod.Custom))
+		rows[i][0] = pod.Namespace
+		rows[i][1] = pod.Name
+		rows[i][2] = fmt.Sprintf("%d/%d", pod.ContainersReady, pod.ContainersCount)
+		rows[i][3] = pod.Status
+		rows[i][4] = fmt.Sprintf("%d", pod.Restarts)
+		rows[i][5] = fmt.Sprintf("%dm", pod.CPU)
+		rows[i][6] = helpers.RenderCPUMax(pod.CPUMax, pod.CPUMaxContainerCount, int64(pod.ContainersCount))
+		rows[i][7] = helpers.FormatBytes(pod.Memory)
+		rows[i][8] = helpers.RenderMemoryMax(pod.MemoryMax, pod.MemoryMaxContainerCount, int64(pod.ContainersCount))
+		rows[i][9] = pod.IP
+		rows[i][10] = helpers.FormatDuration(time.Now().Sub(pod.CreationDate))
+		rows[i][11] = pod.NodeName
+		rows[i][12] = pod.QOSClass
+		rows[i][13] = strings.Join(pod.ControlledBy, ",")
+		copy(rows[i][len(PodColumns):], pod.Custom)
+
+		if p.GaugeMode != GaugeModeAbsolute {
+			rows[i][5] = formatPercent(pod.CPU, pod.CPUMax, rows[i][5])
+			rows[i][7] = formatPercent(pod.Memory, pod.MemoryMax, rows[i][7])
+		}
 	}
 
-	return nil
+	p.SetRows(rows, keys)
+	p.RowColors = alertColors(p.alerts.EvaluatePods(pods, p.filter), keys)
 }
@@ -0,0 +1,115 @@
+package widgets
+
+import (
+	"image"
+	"strings"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// PromptWidget represents the command line at the bottom of the terminal.
+// The prompt is rendered above the statusbar while it is active. The message is shown after the input, e.g. the possible completions or the error of the last command.
+// The prefix is ':' for commands and '/' for the search.
+type PromptWidget struct {
+	*ui.Block
+
+	active  bool
+	prefix  string
+	input   string
+	message string
+	isError bool
+}
+
+// NewPromptWidget returns a new prompt widget.
+func NewPromptWidget() *PromptWidget {
+	block := ui.NewBlock()
+	block.Border = false
+
+	return &PromptWidget{
+		block,
+
+		false,
+		":",
+		"",
+		"",
+		false,
+	}
+}
+
+// Active returns if the prompt is shown.
+func (p *PromptWidget) Active() bool {
+	return p.active
+}
+
+// Show shows an empty prompt with the provided prefix at the bottom of the terminal.
+func (p *PromptWidget) Show(prefix string, termWidth, termHeight int) {
+	p.active = true
+	p.prefix = prefix
+	p.input = ""
+	p.message = ""
+	p.SetRect(0, termHeight-1, termWidth, termHeight)
+}
+
+// Hide hides the prompt.
+func (p *PromptWidget) Hide() {
+	p.active = false
+	p.SetRect(0, 0, 0, 0)
+}
+
+// Prefix returns the prefix of the prompt, which is used to decide if the input is a command or a search.
+func (p *PromptWidget) Prefix() string {
+	return p.prefix
+}
+
+// Input returns the current input of the prompt.
+func (p *PromptWidget) Input() string {
+	return p.input
+}
+
+// SetInput replaces the input of the prompt, e.g. with the completed input.
+func (p *PromptWidget) SetInput(input string) {
+	p.input = input
+	p.message = ""
+}
+
+// Insert adds the provided text at the end of the input.
+func (p *PromptWidget) Insert(text string) {
+	p.SetInput(p.input + text)
+}
+
+// Backspace removes the last character of the input.
+func (p *PromptWidget) Backspace() {
+	runes := []rune(p.input)
+	if len(runes) > 0 {
+		p.SetInput(string(runes[:len(runes)-1]))
+	}
+}
+
+// SetMessage sets the message, which is shown after the input. Errors are rendered in red.
+func (p *PromptWidget) SetMessage(message string, isError bool) {
+	p.message = message
+	p.isError = isError
+}
+
+// Draw renders the prompt.
+func (p *PromptWidget) Draw(buf *ui.Buffer) {
+	if !p.active {
+		return
+	}
+
+	y := p.Inner.Min.Y + (p.Inner.Dy() / 2)
+	input := p.prefix + p.input
+
+	buf.SetString(strings.Repeat(" ", p.Inner.Dx()), ui.NewStyle(ui.ColorClear), image.Pt(p.Inner.Min.X, y))
+	buf.SetString(input, ui.NewStyle(ui.ColorClear), image.Pt(p.Inner.Min.X, y))
+	buf.SetCell(ui.NewCell(' ', ui.NewStyle(ui.ColorBlack, ui.ColorWhite)), image.Pt(p.Inner.Min.X+len([]rune(input)), y))
+
+	if p.message != "" {
+		style := ui.NewStyle(ui.ColorYellow)
+		if p.isError {
+			style = ui.NewStyle(ui.ColorRed)
+		}
+
+		buf.SetString(ui.TrimString(p.message, p.Inner.Dx()-len([]rune(input))-3), style, image.Pt(p.Inner.Min.X+len([]rune(input))+3, y))
+	}
+}
@@ -14,15 +14,26 @@ import (
 type StatusbarWidget struct {
 	*ui.Block
 
-	apiClient *api.Client
+	apiClient api.Source
 	filter    api.Filter
 	pause     bool
+	marked    int
+	replay    string
 	sortorder api.Sort
 	viewType  ViewType
+	items     []statusbarItem
+}
+
+// statusbarItem is an item of the statusbar, which can be clicked to trigger the action of the key (e.g. '[F1] Sorted by ...').
+// The item starts at minX and ends before maxX.
+type statusbarItem struct {
+	key  string
+	minX int
+	maxX int
 }
 
 // NewStatusbarWidget returns a new statusbar widget.
-func NewStatusbarWidget(apiClient *api.Client, filter api.Filter, pause bool, sortorder api.Sort, viewType ViewType, termWidth, termHeight int) *StatusbarWidget {
+func NewStatusbarWidget(apiClient api.Source, filter api.Filter, pause bool, sortorder api.Sort, viewType ViewType, termWidth, termHeight int) *StatusbarWidget {
 	bar := ui.NewBlock()
 	bar.Border = false
 
@@ -34,13 +45,18 @@ func NewStatusbarWidget(apiClient *api.Client, filter api.Filter, pause bool, so
 		apiClient,
 		filter,
 		pause,
+		0,
+		"",
 		sortorder,
 		viewType,
+		nil,
 	}
 }
 
 // Draw renders our statusbar.
 func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
+	s.items = nil
+
 	var paused string
 	if s.pause {
 		paused = "[P] Paused"
@@ -48,28 +64,30 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 		paused = "[P] Updated"
 	}
 
+	// The number of marked rows is shown in front of the pause state, so that the user knows to how many rows a bulk action is applied.
+	if s.marked > 0 {
+		paused = fmt.Sprintf("%d marked  %s", s.marked, paused)
+	}
+
+	// The state of a replay is shown in front of the pause state, so that the user always knows which point in time is shown.
+	if s.replay != "" {
+		paused = s.replay + "  " + paused
+	}
+
 	// Render an string of spaces to set the background for the whole statusbar to green.
 	buf.SetString(
 		strings.Repeat(" ", s.Inner.Dx()),
-		ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
+		theme.Statusbar,
 		image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
 	)
 
 	if s.viewType == ViewTypeNodes {
 		// Render sortorder.
-		sortorder := fmt.Sprintf("[F1] Sorted by %s", string(s.sortorder))
-		buf.SetString(
-			sortorder,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		sortorder := fmt.Sprintf("[F1] Sorted by %s", s.sortorder.String())
+		s.drawItem(buf, sortorder, image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render pause.
-		buf.SetString(
-			paused,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, paused, image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render clustername.
 		// Also calculate the position where the clustername is shown.
@@ -80,19 +98,11 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			clusternameX = s.Inner.Min.X + len(sortorder) + 2 + len(paused) + 10
 		}
 
-		buf.SetString(
-			clustername,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, clustername, image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 	} else if s.viewType == ViewTypePods {
 		// Render sortorder.
-		sortorder := fmt.Sprintf("[F1] Sorted by %s", string(s.sortorder))
-		buf.SetString(
-			sortorder,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		sortorder := fmt.Sprintf("[F1] Sorted by %s", s.sortorder.String())
+		s.drawItem(buf, sortorder, image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render namespace filter.
 		filterNamespace := fmt.Sprintf("[F2] Namespace: %s", s.filter.Namespace)
@@ -100,11 +110,7 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterNamespace = "[F2] Namespace: -"
 		}
 
-		buf.SetString(
-			filterNamespace,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterNamespace, image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render node filter.
 		filterNode := fmt.Sprintf("[F3] Node: %s", s.filter.Node)
@@ -112,11 +118,7 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterNode = "[F3] Node: -"
 		}
 
-		buf.SetString(
-			filterNode,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterNode, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render status filter.
 		var filterStatus string
@@ -131,18 +133,18 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterStatus = "[F4] Status: Terminated"
 		}
 
-		buf.SetString(
-			filterStatus,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterStatus, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
+
+		// Render the selector filter.
+		// The selector is only set when we jump to the pods of a workload, so we only show it when it is not empty.
+		var filterSelector string
+		if s.filter.Selector != "" {
+			filterSelector = fmt.Sprintf("Selector: %s", s.filter.Selector)
+			paused = filterSelector + "  " + paused
+		}
 
 		// Render pause.
-		buf.SetString(
-			paused,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterStatus)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, paused, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterStatus)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render clustername.
 		// Also calculate the position where the clustername is shown.
@@ -153,18 +155,10 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			clusternameX = s.Inner.Min.X + len(sortorder) + 2 + len(filterNamespace) + 2 + len(filterNode) + 2 + len(filterStatus) + 2 + len(paused) + 10
 		}
 
-		buf.SetString(
-			clustername,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, clustername, image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 	} else if s.viewType == ViewTypePodDetails {
 		// Render pause.
-		buf.SetString(
-			paused,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, paused, image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render clustername.
 		// Also calculate the position where the clustername is shown.
@@ -175,19 +169,11 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			clusternameX = s.Inner.Min.X + len(paused) + 10
 		}
 
-		buf.SetString(
-			clustername,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, clustername, image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 	} else if s.viewType == ViewTypeEvents {
 		// Render sortorder.
-		sortorder := fmt.Sprintf("[F1] Sorted by %s", string(s.sortorder))
-		buf.SetString(
-			sortorder,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		sortorder := fmt.Sprintf("[F1] Sorted by %s", s.sortorder.String())
+		s.drawItem(buf, sortorder, image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render namespace filter.
 		filterNamespace := fmt.Sprintf("[F2] Namespace: %s", s.filter.Namespace)
@@ -195,11 +181,7 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterNamespace = "[F2] Namespace: -"
 		}
 
-		buf.SetString(
-			filterNamespace,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterNamespace, image.Pt(s.Inner.Min.X+len(sortorder)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render node filter.
 		filterNode := fmt.Sprintf("[F3] Node: %s", s.filter.Node)
@@ -207,11 +189,7 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterNode = "[F3] Node: -"
 		}
 
-		buf.SetString(
-			filterNode,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterNode, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render event type filter.
 		filterType := fmt.Sprintf("[F4] Event Type: %s", s.filter.EventType)
@@ -219,40 +197,32 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			filterType = "[F4] Event Type: -"
 		}
 
-		buf.SetString(
-			filterType,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, filterType, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
+
+		// Render reason filter.
+		filterReason := fmt.Sprintf("[F5] Reason: %s", s.filter.Reason)
+		if s.filter.Reason == "" {
+			filterReason = "[F5] Reason: -"
+		}
+
+		s.drawItem(buf, filterReason, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterType)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render pause.
-		buf.SetString(
-			paused,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterType)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, paused, image.Pt(s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterType)+2+len(filterReason)+2, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render clustername.
 		// Also calculate the position where the clustername is shown.
 		// The clustername is right aligned and if the terminal window is to small we cut of a part of the name.
 		clustername := s.apiClient.GetClustername()
 		clusternameX := s.Inner.Max.X - len(clustername)
-		if s.Inner.Max.X-len(clustername) < s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterType)+2+len(paused)+10 {
-			clusternameX = s.Inner.Min.X + len(sortorder) + 2 + len(filterNamespace) + 2 + len(filterNode) + 2 + len(filterType) + 2 + len(paused) + 10
+		if s.Inner.Max.X-len(clustername) < s.Inner.Min.X+len(sortorder)+2+len(filterNamespace)+2+len(filterNode)+2+len(filterType)+2+len(filterReason)+2+len(paused)+10 {
+			clusternameX = s.Inner.Min.X + len(sortorder) + 2 + len(filterNamespace) + 2 + len(filterNode) + 2 + len(filterType) + 2 + len(filterReason) + 2 + len(paused) + 10
 		}
 
-		buf.SetString(
-			clustername,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, clustername, image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 	} else if s.viewType == ViewTypeEventDetails {
 		// Render pause.
-		buf.SetString(
-			paused,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, paused, image.Pt(s.Inner.Min.X, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 
 		// Render clustername.
 		// Also calculate the position where the clustername is shown.
@@ -263,19 +233,62 @@ func (s *StatusbarWidget) Draw(buf *ui.Buffer) {
 			clusternameX = s.Inner.Min.X + len(paused) + 10
 		}
 
-		buf.SetString(
-			clustername,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
-			image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)),
-		)
+		s.drawItem(buf, clustername, image.Pt(clusternameX, s.Inner.Min.Y+(s.Inner.Dy()/2)))
 	}
 }
 
+// drawItem renders a text of the statusbar at the provided position.
+// If the text contains a key in brackets (e.g. '[F1]' or '[P]'), we remember the position of the text, so that a click on the text triggers the action of the key.
+func (s *StatusbarWidget) drawItem(buf *ui.Buffer, text string, point image.Point) {
+	buf.SetString(text, theme.Statusbar, point)
+
+	start := strings.Index(text, "[")
+	end := strings.Index(text, "]")
+	if start == -1 || end <= start+1 {
+		return
+	}
+
+	key := text[start+1 : end]
+	if key == "P" {
+		key = "p"
+	} else {
+		key = "<" + key + ">"
+	}
+
+	s.items = append(s.items, statusbarItem{key, point.X + start, point.X + len(text)})
+}
+
+// KeyAt returns the key of the statusbar item at the provided position.
+// This is used to open the corresponding list, when the user clicks on an item of the statusbar.
+func (s *StatusbarWidget) KeyAt(x, y int) (string, bool) {
+	if y < s.Min.Y || y >= s.Max.Y {
+		return "", false
+	}
+
+	for _, item := range s.items {
+		if x >= item.minX && x < item.maxX {
+			return item.key, true
+		}
+	}
+
+	return "", false
+}
+
 // SetPause sets a new value for pause.
 func (s *StatusbarWidget) SetPause(pause bool) {
 	s.pause = pause
 }
 
+// SetMarked sets the number of marked rows in the current view.
+func (s *StatusbarWidget) SetMarked(marked int) {
+	s.marked = marked
+}
+
+// SetReplay sets the state of the replay, which is shown in the statusbar. An empty state is not shown.
+func (s *StatusbarWidget) SetReplay(replay string) {
+	s.replay = replay
+}
+
 // SetSortAndFilter sets a new value for the sortorder and filter.
 func (s *StatusbarWidget) SetSortAndFilter(sortorder api.Sort, filter api.Filter) {
 	s.sortorder = sortorder
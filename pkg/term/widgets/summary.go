@@ -0,0 +1,126 @@
+package widgets
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/ricoberger/kubetop/pkg/api"
+	"github.com/ricoberger/kubetop/pkg/term/helpers"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// SummaryHeight is the number of lines which are used by the summary widget above the table views.
+const SummaryHeight = 2
+
+// summaryMaxAge is the maximum age of the data for resources, which are not shown in the current view.
+// We use a larger value than the refresh interval of the views, because the data for these resources is fetched only for the summary.
+const summaryMaxAge = 30 * time.Second
+
+// SummaryWidget represents the ui widget component for the cluster summary, which is shown above the nodes, pods and events view.
+type SummaryWidget struct {
+	*ui.Block
+
+	apiClient api.Source
+	summary   *api.Summary
+	viewType  ViewType
+}
+
+// NewSummaryWidget returns a new summary widget.
+func NewSummaryWidget(apiClient api.Source, viewType ViewType, termWidth, termHeight int) *SummaryWidget {
+	block := ui.NewBlock()
+	block.Border = false
+
+	block.SetRect(0, 0, termWidth, SummaryHeight)
+
+	return &SummaryWidget{
+		block,
+
+		apiClient,
+		nil,
+		viewType,
+	}
+}
+
+// SetViewType sets the view type, to know if the summary should be rendered.
+func (s *SummaryWidget) SetViewType(viewType ViewType) {
+	s.viewType = viewType
+}
+
+// Visible returns true if the summary is rendered for the provided view type.
+// The summary is only rendered above the table views, the details views are using the whole terminal.
+func (s *SummaryWidget) Visible(viewType ViewType) bool {
+	return viewType == ViewTypeNodes || viewType == ViewTypePods || viewType == ViewTypeEvents
+}
+
+// Update updates the cluster summary.
+func (s *SummaryWidget) Update() error {
+	return update(s.Fetch())
+}
+
+// Fetch returns the function to get the cluster summary. Like for the views the summary can be fetched without holding the lock of the term.
+func (s *SummaryWidget) Fetch() FetchFunc {
+	if !s.Visible(s.viewType) {
+		return fetchNothing
+	}
+
+	return func() (func(), error) {
+		summary, err := s.apiClient.GetSummary(summaryMaxAge)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() {
+			s.summary = summary
+		}, nil
+	}
+}
+
+// Draw renders the cluster summary.
+// Not ready nodes and warning events are highlighted, so that problems in the cluster can be seen at a glance.
+func (s *SummaryWidget) Draw(buf *ui.Buffer) {
+	if !s.Visible(s.viewType) || s.summary == nil {
+		return
+	}
+
+	var cpuPercent, memoryPercent float64
+	if s.summary.CPUAllocatable > 0 {
+		cpuPercent = float64(s.summary.CPUUsed) * 100.0 / float64(s.summary.CPUAllocatable)
+	}
+	if s.summary.MemoryAllocatable > 0 {
+		memoryPercent = float64(s.summary.MemoryUsed) * 100.0 / float64(s.summary.MemoryAllocatable)
+	}
+
+	notReadyColor := ui.ColorClear
+	if s.summary.NodesNotReady > 0 {
+		notReadyColor = ui.ColorRed
+	}
+
+	warningsColor := ui.ColorClear
+	if s.summary.WarningEvents > 0 {
+		warningsColor = ui.ColorYellow
+	}
+
+	segments := []struct {
+		text  string
+		color ui.Color
+	}{
+		{fmt.Sprintf("Nodes: %d Ready, ", s.summary.NodesReady), ui.ColorClear},
+		{fmt.Sprintf("%d NotReady", s.summary.NodesNotReady), notReadyColor},
+		{fmt.Sprintf("    Pods: %d Running, %d Waiting, %d Terminated", s.summary.PodsRunning, s.summary.PodsWaiting, s.summary.PodsTerminated), ui.ColorClear},
+		{fmt.Sprintf("    CPU: %dm / %dm (%.2f%%)", s.summary.CPUUsed, s.summary.CPUAllocatable, cpuPercent), ui.ColorClear},
+		{fmt.Sprintf("    Memory: %s / %s (%.2f%%)", helpers.FormatBytes(s.summary.MemoryUsed), helpers.FormatBytes(s.summary.MemoryAllocatable), memoryPercent), ui.ColorClear},
+		{fmt.Sprintf("    Warnings (5m): %d", s.summary.WarningEvents), warningsColor},
+	}
+
+	x := s.Inner.Min.X + 1
+	for _, segment := range segments {
+		buf.SetString(
+			segment.text,
+			ui.NewStyle(segment.color),
+			image.Pt(x, s.Inner.Min.Y),
+		)
+		x = x + len(segment.text)
+	}
+}
@@ -0,0 +1,90 @@
+package widgets
+
+import (
+	"fmt"
+	"image"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// TabBarHeight is the number of lines which are used by the tab bar at the top of the terminal.
+const TabBarHeight = 1
+
+// TabBarWidget represents the line at the top of the terminal, which shows all open tabs.
+// The tab bar is only rendered when more than one tab is open. To switch to a tab by a click, we remember the start and the end of each tab.
+type TabBarWidget struct {
+	*ui.Block
+
+	tabs      []string
+	active    int
+	positions []tabPosition
+}
+
+// tabPosition is the position of a tab in the tab bar. The tab starts at minX and ends before maxX.
+type tabPosition struct {
+	minX int
+	maxX int
+}
+
+// NewTabBarWidget returns a new tab bar widget.
+func NewTabBarWidget() *TabBarWidget {
+	block := ui.NewBlock()
+	block.Border = false
+
+	return &TabBarWidget{
+		block,
+
+		nil,
+		0,
+		nil,
+	}
+}
+
+// SetTabs sets the labels of all tabs and the index of the active tab.
+func (t *TabBarWidget) SetTabs(tabs []string, active int) {
+	t.tabs = tabs
+	t.active = active
+}
+
+// Visible returns true if the tab bar is rendered.
+func (t *TabBarWidget) Visible() bool {
+	return len(t.tabs) > 1
+}
+
+// Draw renders the tab bar. The active tab is rendered with the colors of the statusbar.
+func (t *TabBarWidget) Draw(buf *ui.Buffer) {
+	t.positions = nil
+	if !t.Visible() {
+		return
+	}
+
+	y := t.Inner.Min.Y + (t.Inner.Dy() / 2)
+	x := t.Inner.Min.X
+	for index, label := range t.tabs {
+		text := fmt.Sprintf(" %d: %s ", index+1, label)
+		t.positions = append(t.positions, tabPosition{x, x + len([]rune(text))})
+
+		style := ui.NewStyle(ui.ColorClear)
+		if index == t.active {
+			style = theme.Statusbar
+		}
+
+		buf.SetString(text, style, image.Pt(x, y))
+		x = x + len([]rune(text)) + 1
+	}
+}
+
+// TabAt returns the index of the tab at the provided position.
+func (t *TabBarWidget) TabAt(x, y int) (int, bool) {
+	if !t.Visible() || y < t.Min.Y || y >= t.Max.Y {
+		return 0, false
+	}
+
+	for index, position := range t.positions {
+		if x >= position.minX && x < position.maxX {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
@@ -5,6 +5,8 @@ import (
 	"image"
 	"strings"
 
+	"github.com/ricoberger/kubetop/pkg/api"
+
 	ui "github.com/gizak/termui/v3"
 )
 
@@ -17,13 +19,32 @@ type Table struct {
 	Header []string
 	Rows   [][]string
 
+	// Keys contains a stable key for each row (e.g. '<namespace>/<name>' or the uid of an object), which is not rendered.
+	// The key is used to keep the selection on the same object, when the rows are reordered or removed. If no keys are set, the value of the unique column is used as key.
+	Keys []string
+
 	ColWidths []int
 	ColGap    int
 	PadLeft   int
 
+	// Columns is the column model of the table. If it is set, the header, the widths and the order of the columns are calculated from the model.
+	// ColOrder is the order in which the columns are rendered, if it is not set the columns are rendered in the order of the rows.
+	Columns    []Column
+	ColOrder   []int
+	hiddenCols int
+
+	// Sort is the sortorder of the rows. It is only used to render the sort indicator in the header of the sorted columns.
+	Sort api.Sort
+
 	ShowCursor  bool
 	CursorColor ui.Color
 
+	RowColors map[int]ui.Color
+
+	GaugeCols       map[int]bool
+	GaugeMode       GaugeMode
+	GaugeThresholds Thresholds
+
 	ShowLocation bool
 
 	UniqueCol    int
@@ -31,6 +52,9 @@ type Table struct {
 	SelectedRow  int
 	TopRow       int
 
+	// marked contains the keys of all marked rows.
+	marked map[string]bool
+
 	ColResizer func()
 }
 
@@ -41,6 +65,9 @@ func NewTable() *Table {
 
 		ShowCursor: true,
 
+		GaugeMode:       GaugeModeBars,
+		GaugeThresholds: Thresholds{Warning: 70, Critical: 90},
+
 		UniqueCol:   0,
 		SelectedRow: 0,
 		TopRow:      0,
@@ -58,18 +85,29 @@ func (t *Table) Draw(buf *ui.Buffer) {
 	}
 
 	t.ColResizer()
+	if t.Columns != nil {
+		t.layoutColumns()
+	}
+
+	order := t.ColOrder
+	if order == nil {
+		for i := range t.ColWidths {
+			order = append(order, i)
+		}
+	}
 
 	// Finds exact column starting position.
-	colXPos := []int{}
+	colXPos := make([]int, len(t.ColWidths))
 	cur := 1 + t.PadLeft
-	for _, w := range t.ColWidths {
-		colXPos = append(colXPos, cur)
-		cur += w
+	for _, i := range order {
+		colXPos[i] = cur
+		cur += t.ColWidths[i]
 		cur += t.ColGap
 	}
 
 	// Prints the header.
-	for i, h := range t.Header {
+	for _, i := range order {
+		h := t.Header[i]
 		width := t.ColWidths[i]
 		if width == 0 {
 			continue
@@ -82,17 +120,27 @@ func (t *Table) Draw(buf *ui.Buffer) {
 		// Render the background of the header.
 		buf.SetString(
 			strings.Repeat(" ", t.Inner.Dx()),
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
+			theme.Header,
 			image.Pt(t.Inner.Min.X+colXPos[i]-1, t.Inner.Min.Y),
 		)
 
 		buf.SetString(
-			h,
-			ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
+			ui.TrimString(h, width),
+			theme.Header,
 			image.Pt(t.Inner.Min.X+colXPos[i]-1, t.Inner.Min.Y),
 		)
 	}
 
+	// Show the number of columns, which do not fit into the table.
+	if t.hiddenCols > 0 {
+		indicator := fmt.Sprintf(" +%d ", t.hiddenCols)
+		buf.SetString(
+			indicator,
+			ui.NewStyle(ui.ColorBlack, ui.ColorYellow),
+			image.Pt(t.Inner.Max.X-len(indicator), t.Inner.Min.Y),
+		)
+	}
+
 	if t.TopRow < 0 {
 		return
 	}
@@ -103,11 +151,23 @@ func (t *Table) Draw(buf *ui.Buffer) {
 		y := (rowNum + 2) - t.TopRow
 
 		// Print the cursor / selected row.
+		// If a color is set for the row, we use this color as foreground color for all columns of the row.
+		// Marked rows are rendered with the marked style of the theme.
 		style := ui.NewStyle(ui.ColorClear)
+		if color, ok := t.RowColors[rowNum]; ok {
+			style.Fg = color
+		}
+		if t.Marked(rowNum) {
+			style = theme.Marked
+			buf.SetString(
+				strings.Repeat(" ", t.Inner.Dx()),
+				style,
+				image.Pt(t.Inner.Min.X+1, t.Inner.Min.Y+y-1),
+			)
+		}
 		if t.ShowCursor {
-			if (t.SelectedItem == "" && rowNum == t.SelectedRow) || (t.SelectedItem != "" && t.SelectedItem == row[t.UniqueCol]) {
-				style.Fg = ui.ColorBlack
-				style.Bg = ui.ColorCyan
+			if (t.SelectedItem == "" && rowNum == t.SelectedRow) || (t.SelectedItem != "" && t.SelectedItem == t.RowKey(rowNum)) {
+				style = theme.Cursor
 				for _, width := range t.ColWidths {
 					if width == 0 {
 						continue
@@ -118,13 +178,14 @@ func (t *Table) Draw(buf *ui.Buffer) {
 						image.Pt(t.Inner.Min.X+1, t.Inner.Min.Y+y-1),
 					)
 				}
-				t.SelectedItem = row[t.UniqueCol]
+				t.SelectedItem = t.RowKey(rowNum)
 				t.SelectedRow = rowNum
 			}
 		}
 
 		// Print each column of the row.
-		for i, width := range t.ColWidths {
+		for _, i := range order {
+			width := t.ColWidths[i]
 			if width == 0 {
 				continue
 			}
@@ -132,6 +193,14 @@ func (t *Table) Draw(buf *ui.Buffer) {
 			if width > (t.Inner.Dx()-colXPos[i])+1 {
 				continue
 			}
+			// Cells of gauge columns are rendered as bar or colored percentage, when they contain a percentage.
+			if t.GaugeCols[i] && t.GaugeMode != GaugeModeAbsolute {
+				if percent, ok := parsePercent(row[i]); ok {
+					t.drawGauge(buf, percent, width, style, image.Pt(t.Inner.Min.X+colXPos[i]-1, t.Inner.Min.Y+y-1))
+					continue
+				}
+			}
+
 			r := ui.TrimString(row[i], width)
 			buf.SetString(
 				r,
@@ -142,6 +211,51 @@ func (t *Table) Draw(buf *ui.Buffer) {
 	}
 }
 
+// RowKey returns the key of the row with the provided index.
+func (t *Table) RowKey(row int) string {
+	if row < 0 || row >= len(t.Rows) {
+		return ""
+	}
+
+	if len(t.Keys) == len(t.Rows) {
+		return t.Keys[row]
+	}
+
+	return t.Rows[row][t.UniqueCol]
+}
+
+// SetRows replaces the rows and the keys of the table.
+// The selection is moved to the row with the key of the previously selected row, so that the same object is selected after the rows were reordered. If the object was removed, the row at the same position is selected.
+func (t *Table) SetRows(rows [][]string, keys []string) {
+	selected := t.SelectedItem
+	if selected == "" {
+		selected = t.RowKey(t.SelectedRow)
+	}
+
+	t.Rows = rows
+	t.Keys = keys
+
+	for index := range t.Rows {
+		if selected != "" && t.RowKey(index) == selected {
+			t.SelectedRow = index
+			break
+		}
+	}
+
+	t.calcPos()
+	t.SelectedItem = t.RowKey(t.SelectedRow)
+	t.pruneMarks()
+}
+
+// SelectedRowValues returns the values of the selected row. If the table is empty nil is returned.
+func (t *Table) SelectedRowValues() []string {
+	if t.SelectedRow < 0 || t.SelectedRow >= len(t.Rows) {
+		return nil
+	}
+
+	return t.Rows[t.SelectedRow]
+}
+
 // drawLocation renders the current location.
 func (t *Table) drawLocation(buf *ui.Buffer) {
 	total := len(t.Rows)
@@ -224,12 +338,43 @@ func (t *Table) ScrollPageDown() {
 	t.calcPos()
 }
 
-// HandleClick handles a click.
-func (t *Table) HandleClick(x, y int) {
+// SelectValue selects the first row, which contains the provided value in the provided column.
+// It returns false if there is no such row.
+func (t *Table) SelectValue(col int, value string) bool {
+	for index, row := range t.Rows {
+		if col < len(row) && row[col] == value {
+			t.SelectedRow = index
+			t.calcPos()
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelectKey selects the row with the provided key. It returns false if there is no such row.
+func (t *Table) SelectKey(key string) bool {
+	for index := range t.Rows {
+		if t.RowKey(index) == key {
+			t.SelectedRow = index
+			t.calcPos()
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleClick selects the row at the clicked position.
+// It returns false if the position is not a row of the table, e.g. when the header of the table was clicked.
+func (t *Table) HandleClick(x, y int) bool {
 	x = x - t.Min.X
 	y = y - t.Min.Y
-	if (x > 0 && x <= t.Inner.Dx()) && (y > 0 && y <= t.Inner.Dy()) {
+	if (x > 0 && x <= t.Inner.Dx()) && (y > 1 && y <= t.Inner.Dy()) && t.TopRow+y-2 < len(t.Rows) {
 		t.SelectedRow = (t.TopRow + y) - 2
 		t.calcPos()
+		return true
 	}
+
+	return false
 }
@@ -0,0 +1,94 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+var (
+	// ErrInvalidColor is thrown if a color of a theme is not a known color name or a number between -1 and 255.
+	ErrInvalidColor = errors.New("invalid color")
+)
+
+// Theme represents the colors of kubetop.
+// The header style is used for the header of all tables, the cursor style for the selected row of a table and the statusbar style for the statusbar at the bottom of the terminal.
+// The list style is used for the text of the sort and filter lists and the column picker. The marked style is used for the rows, which were marked for a bulk action.
+type Theme struct {
+	Header    ui.Style
+	Cursor    ui.Style
+	Statusbar ui.Style
+	List      ui.Style
+	Marked    ui.Style
+}
+
+// Themes contains the built-in themes, which can be selected by their name in the configuration file.
+var Themes = map[string]Theme{
+	"default": {
+		Header:    ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
+		Cursor:    ui.NewStyle(ui.ColorBlack, ui.ColorCyan),
+		Statusbar: ui.NewStyle(ui.ColorBlack, ui.ColorGreen),
+		List:      ui.NewStyle(ui.ColorYellow),
+		Marked:    ui.NewStyle(ui.ColorBlack, ui.ColorMagenta),
+	},
+	"blue": {
+		Header:    ui.NewStyle(ui.ColorWhite, ui.ColorBlue),
+		Cursor:    ui.NewStyle(ui.ColorBlack, ui.ColorYellow),
+		Statusbar: ui.NewStyle(ui.ColorWhite, ui.ColorBlue),
+		List:      ui.NewStyle(ui.ColorCyan),
+		Marked:    ui.NewStyle(ui.ColorWhite, ui.ColorMagenta),
+	},
+	"monochrome": {
+		Header:    ui.NewStyle(ui.ColorBlack, ui.ColorWhite),
+		Cursor:    ui.NewStyle(ui.ColorBlack, ui.ColorWhite, ui.ModifierBold),
+		Statusbar: ui.NewStyle(ui.ColorBlack, ui.ColorWhite),
+		List:      ui.NewStyle(ui.ColorWhite),
+		Marked:    ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierUnderline),
+	},
+}
+
+// theme is the theme which is used to render all widgets.
+// We use a package wide theme, because the theme is used by all tables and lists and can not be changed while kubetop is running.
+var theme = Themes["default"]
+
+// SetTheme sets the theme which is used to render all widgets.
+// The theme must be set before the widgets are created.
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// colorNames maps the names of the colors, which can be used in the configuration file, to the termui colors.
+var colorNames = map[string]ui.Color{
+	"clear":   ui.ColorClear,
+	"black":   ui.ColorBlack,
+	"red":     ui.ColorRed,
+	"green":   ui.ColorGreen,
+	"yellow":  ui.ColorYellow,
+	"blue":    ui.ColorBlue,
+	"magenta": ui.ColorMagenta,
+	"cyan":    ui.ColorCyan,
+	"white":   ui.ColorWhite,
+}
+
+// ParseColor returns the color for the provided name.
+// Besides the names of the eight basic colors, the number of a color in the 256 color palette can be used. An empty name is the default color of the terminal.
+func ParseColor(name string) (ui.Color, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ui.ColorClear, nil
+	}
+
+	if color, ok := colorNames[name]; ok {
+		return color, nil
+	}
+
+	number, err := strconv.Atoi(name)
+	if err != nil || number < -1 || number > 255 {
+		return ui.ColorClear, fmt.Errorf("%v: %s", ErrInvalidColor, name)
+	}
+
+	return ui.Color(number), nil
+}
@@ -6,10 +6,18 @@ import (
 	ui "github.com/gizak/termui/v3"
 )
 
+// FetchFunc fetches the data of a view from the Kubernetes API and returns the function, which shows the fetched data in the view.
+type FetchFunc func() (func(), error)
+
 // View represents all widgets which can be rendered as seperate view.
+// The data of a view can be fetched without holding the lock of the term: Fetch must be called while the view is not changed, but the returned function only uses the settings of the view at the time of the call.
+// The data is not shown, when the filter, the sortorder or the pause state of the view were changed in the meantime. Update fetches and shows the data at once.
+// Close is called when the view is not used anymore, so that it can stop its work in the background.
 type View interface {
 	ui.Drawable
 
+	Close()
+	Fetch() FetchFunc
 	Filter() api.Filter
 	Pause() bool
 	SelectedValues() []string
@@ -23,6 +31,7 @@ type View interface {
 	SelectPageUp()
 	SetSortAndFilter(sortorder api.Sort, filter api.Filter)
 	Sortorder() api.Sort
+	ToggleFocus()
 	TogglePause()
 	Update() error
 }
@@ -47,3 +56,19 @@ const (
 	// The event details view is represented by the EventDetailsWidget.
 	ViewTypeEventDetails ViewType = "Event Details View"
 )
+
+// update fetches the data of a view and shows it directly.
+func update(fetch FetchFunc) error {
+	apply, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	apply()
+	return nil
+}
+
+// fetchNothing is used as FetchFunc for paused views.
+func fetchNothing() (func(), error) {
+	return func() {}, nil
+}